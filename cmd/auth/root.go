@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -20,6 +21,8 @@ var (
 	pbPassword      string
 	pbCollection    string
 	pbPasswordStdin bool
+	pbPasswordFile  string
+	pbOTP           bool
 )
 
 // AuthCmd represents the auth command
@@ -39,9 +42,22 @@ The authentication will:
   2. Store the session token securely in your context
   3. Enable access to collections and operations
 
+With secure_token_storage enabled in the global config, the token is kept in the
+OS keyring (macOS Keychain, or the Secret Service via secret-tool on Linux)
+instead of the context file. Windows has no keyring support yet: leave
+secure_token_storage off there.
+
 Credentials are resolved in this order:
   email:    --email flag  > PB_EMAIL env    > interactive prompt
-  password: --password    > --password-stdin > PB_PASSWORD env > interactive prompt
+  password: --password    > --password-stdin > --password-file > PB_PASSWORD env >
+            interactive prompt
+
+Avoid --password in scripts: it ends up in shell history and is visible to other
+users in the process list. Use --password-stdin, --password-file, or PB_PASSWORD.
+
+With --otp, no password is used: PocketBase emails a one-time code to the
+address, and you are prompted to enter it. This requires OTP to be enabled on
+the auth collection.
 
 Examples:
   # Interactive authentication (prompts for credentials)
@@ -53,53 +69,73 @@ Examples:
   # Non-interactive / CI (no password in argv or shell history)
   PB_EMAIL=ci@example.com PB_PASSWORD=secret pb auth
   echo "$PB_PASSWORD" | pb auth --email ci@example.com --password-stdin
+  pb auth --email ci@example.com --password-file ~/.config/pb/ci-password
 
   # Authenticate as a superuser (needed for backups and 'pb schema')
   pb auth --collection _superusers --email admin@example.com
 
-  # Check status or clear the stored token
+  # Authenticate with a one-time code sent by email
+  pb auth --otp --email user@example.com
+
+  # Check status, extend the session, or clear the stored token
   pb auth status
+  pb auth refresh
   pb auth logout`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		ctx, err := validateActiveContext()
-		if err != nil {
-			return err
-		}
+		return Authenticate()
+	},
+}
 
-		// Use collection from context if not specified, default to users
-		if pbCollection == "" {
-			if ctx.PocketBase.AuthCollection != "" {
-				pbCollection = ctx.PocketBase.AuthCollection
-			} else {
-				pbCollection = config.AuthCollectionUsers
-			}
-		}
+// Authenticate authenticates the active context and saves the token, resolving
+// credentials from the auth flags, then the environment, then prompts. Other
+// commands call it to run the same flow as 'pb auth' without flags.
+func Authenticate() error {
+	ctx, err := validateActiveContext()
+	if err != nil {
+		return err
+	}
 
-		// Validate collection
-		if err := config.ValidateAuthCollection(pbCollection); err != nil {
-			return err
+	// Use collection from context if not specified, default to users
+	if pbCollection == "" {
+		if ctx.PocketBase.AuthCollection != "" {
+			pbCollection = ctx.PocketBase.AuthCollection
+		} else {
+			pbCollection = config.AuthCollectionUsers
 		}
+	}
 
-		// Resolve email: --email flag > PB_EMAIL env > interactive prompt.
-		if pbEmail == "" {
-			pbEmail = os.Getenv("PB_EMAIL")
-		}
-		if pbEmail == "" {
-			pbEmail, err = promptForEmail()
-			if err != nil {
-				return fmt.Errorf("failed to get email: %w", err)
-			}
+	// Validate collection
+	if err := config.ValidateAuthCollection(pbCollection); err != nil {
+		return err
+	}
+
+	// Resolve email: --email flag > PB_EMAIL env > interactive prompt.
+	if pbEmail == "" {
+		pbEmail = os.Getenv("PB_EMAIL")
+	}
+	if pbEmail == "" {
+		pbEmail, err = promptForEmail()
+		if err != nil {
+			return fmt.Errorf("failed to get email: %w", err)
 		}
+	}
 
-		// Resolve password: --password flag > --password-stdin > PB_PASSWORD env >
-		// interactive prompt. This lets CI authenticate without a TTY and without
-		// leaking the password into argv/shell history.
+	// Resolve password: --password flag > --password-stdin > --password-file >
+	// PB_PASSWORD env > interactive prompt. This lets CI authenticate without a TTY and without
+	// leaking the password into argv/shell history. OTP auth has no password.
+	if !pbOTP {
 		if pbPassword == "" && pbPasswordStdin {
 			pbPassword, err = readPasswordStdin()
 			if err != nil {
 				return fmt.Errorf("failed to read password from stdin: %w", err)
 			}
 		}
+		if pbPassword == "" && pbPasswordFile != "" {
+			pbPassword, err = readPasswordFile(pbPasswordFile)
+			if err != nil {
+				return err
+			}
+		}
 		if pbPassword == "" {
 			pbPassword = os.Getenv("PB_PASSWORD")
 		}
@@ -109,77 +145,81 @@ Examples:
 				return fmt.Errorf("failed to get password: %w", err)
 			}
 		}
+	}
 
-		// Basic email validation
-		if pbEmail == "" || !strings.Contains(pbEmail, "@") {
-			return fmt.Errorf("invalid email format")
-		}
+	// Basic email validation
+	if pbEmail == "" || !strings.Contains(pbEmail, "@") {
+		return fmt.Errorf("invalid email format")
+	}
 
-		// Create PocketBase client
-		client := pocketbase.NewClient(ctx.PocketBase.URL)
+	// Create PocketBase client
+	client := pocketbase.NewClient(ctx.PocketBase.URL)
 
-		// Test connection first
-		utils.PrintInfo("Testing connection to PocketBase...")
-		if err := client.GetHealth(); err != nil {
-			return fmt.Errorf("failed to connect to PocketBase at %s: %w", ctx.PocketBase.URL, err)
-		}
+	// Test connection first
+	utils.PrintInfo("Testing connection to PocketBase...")
+	if err := client.GetHealth(); err != nil {
+		return fmt.Errorf("failed to connect to PocketBase at %s: %w", ctx.PocketBase.URL, err)
+	}
 
-		// Perform authentication
+	// Perform authentication
+	var authResp *pocketbase.AuthResponse
+	if pbOTP {
+		authResp, err = authenticateWithOTP(client, pbCollection, pbEmail)
+	} else {
 		utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", pbCollection))
-
-		authResp, err := client.Authenticate(pbCollection, pbEmail, pbPassword)
-		if err != nil {
-			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
-				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
-				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
-					fmt.Printf("\nSuggestion: %s\n", suggestion)
-				}
-				return fmt.Errorf("authentication failed")
+		authResp, err = client.Authenticate(pbCollection, pbEmail, pbPassword)
+	}
+	if err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+				fmt.Printf("\nSuggestion: %s\n", suggestion)
 			}
-			return fmt.Errorf("authentication failed: %w", err)
+			return fmt.Errorf("authentication failed")
 		}
+		return fmt.Errorf("authentication failed: %w", err)
+	}
 
-		// Update context with authentication data
-		if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp); err != nil {
-			return fmt.Errorf("failed to update context: %w", err)
-		}
+	// Update context with authentication data
+	if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp); err != nil {
+		return fmt.Errorf("failed to update context: %w", err)
+	}
 
-		// Update auth collection in context
-		ctx.PocketBase.AuthCollection = pbCollection
+	// Update auth collection in context
+	ctx.PocketBase.AuthCollection = pbCollection
 
-		// Save updated context
-		if err := configManager.SaveContext(ctx); err != nil {
-			return fmt.Errorf("failed to save authentication: %w", err)
-		}
+	// Save updated context
+	if err := configManager.SaveContext(ctx); err != nil {
+		return fmt.Errorf("failed to save authentication: %w", err)
+	}
 
-		// Display success message
-		green := color.New(color.FgGreen).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
+	// Display success message
+	green := color.New(color.FgGreen).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
 
-		fmt.Printf("\n%s Authentication successful!\n", green("✓"))
+	fmt.Printf("\n%s Authentication successful!\n", green("✓"))
 
-		fmt.Printf("\nAuthentication Details:\n")
-		fmt.Printf("  Collection: %s\n", pocketbase.GetCollectionDisplayName(pbCollection))
-		fmt.Printf("  Identity:   %s\n", pbEmail)
-		if ctx.PocketBase.AuthExpires != nil {
-			expiresAtFormatted := ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05 MST")
-			fmt.Printf("  Expires:    %s\n", expiresAtFormatted)
-		}
-		fmt.Printf("  Context:    %s\n", cyan(ctx.Name))
+	fmt.Printf("\nAuthentication Details:\n")
+	fmt.Printf("  Collection: %s\n", pocketbase.GetCollectionDisplayName(pbCollection))
+	fmt.Printf("  Identity:   %s\n", pbEmail)
+	if ctx.PocketBase.AuthExpires != nil {
+		expiresAtFormatted := ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05 MST")
+		fmt.Printf("  Expires:    %s\n", expiresAtFormatted)
+	}
+	fmt.Printf("  Context:    %s\n", cyan(ctx.Name))
 
-		if authResp.Record != nil {
-			if name := getRecordDisplayName(authResp.Record, pbCollection); name != "" {
-				fmt.Printf("  Name:       %s\n", name)
-			}
+	if authResp.Record != nil {
+		if name := pocketbase.Record(authResp.Record).GetDisplayName(); name != "" {
+			fmt.Printf("  Name:       %s\n", name)
 		}
+	}
 
-		// Show available next steps
-		fmt.Printf("\nNext steps:\n")
-		fmt.Printf("  List collections: %s\n", cyan("pb schema"))
-		fmt.Printf("  List records:     %s\n", cyan("pb collections list <collection>"))
+	// Show available next steps
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  List collections: %s\n", cyan("pb schema"))
+	fmt.Printf("  List records:     %s\n", cyan("pb collections list <collection>"))
 
-		return nil
-	},
+	return nil
 }
 
 var configManager *config.Manager
@@ -188,8 +228,17 @@ func init() {
 	AuthCmd.Flags().StringVarP(&pbEmail, "email", "e", "", "Email address (or set PB_EMAIL; prompts if unset)")
 	AuthCmd.Flags().StringVarP(&pbPassword, "password", "p", "", "Password (insecure in shell history; prefer --password-stdin or PB_PASSWORD)")
 	AuthCmd.Flags().BoolVar(&pbPasswordStdin, "password-stdin", false, "Read the password from stdin (for non-interactive/CI use)")
+	AuthCmd.Flags().StringVar(&pbPasswordFile, "password-file", "", "Read the password from this file (first line; should not be readable by others)")
 	AuthCmd.Flags().StringVarP(&pbCollection, "collection", "c", "", "Authentication collection (defaults to context setting or 'users')")
+	AuthCmd.Flags().BoolVar(&pbOTP, "otp", false, "Authenticate with a one-time code emailed by PocketBase instead of a password")
 
+	AuthCmd.MarkFlagsMutuallyExclusive("otp", "password")
+	AuthCmd.MarkFlagsMutuallyExclusive("otp", "password-stdin")
+	AuthCmd.MarkFlagsMutuallyExclusive("otp", "password-file")
+	AuthCmd.MarkFlagsMutuallyExclusive("password", "password-file")
+	AuthCmd.MarkFlagsMutuallyExclusive("password-stdin", "password-file")
+
+	AuthCmd.AddCommand(refreshCmd)
 	AuthCmd.AddCommand(logoutCmd)
 	AuthCmd.AddCommand(statusCmd)
 }
@@ -245,6 +294,37 @@ func promptForPassword() (string, error) {
 	return string(passwordBytes), nil
 }
 
+// promptForOTP prompts the user for the one-time code they received by email
+func promptForOTP() (string, error) {
+	fmt.Print("One-time code: ")
+	reader := bufio.NewReader(os.Stdin)
+	code, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(code), nil
+}
+
+// authenticateWithOTP requests a one-time code for email, prompts for it, and
+// exchanges it for an auth token.
+func authenticateWithOTP(client *pocketbase.Client, collection, email string) (*pocketbase.AuthResponse, error) {
+	utils.PrintInfo(fmt.Sprintf("Requesting one-time code from collection '%s'...", collection))
+
+	otpID, err := client.RequestOTP(collection, email)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.PrintInfo(fmt.Sprintf("A one-time code was sent to %s", email))
+	code, err := promptForOTP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read one-time code: %w", err)
+	}
+
+	utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", collection))
+	return client.AuthenticateWithOTP(collection, otpID, code)
+}
+
 // readPasswordStdin reads a single line (the password) from stdin.
 func readPasswordStdin() (string, error) {
 	scanner := bufio.NewScanner(os.Stdin)
@@ -257,6 +337,91 @@ func readPasswordStdin() (string, error) {
 	return "", fmt.Errorf("no password provided on stdin")
 }
 
+// readPasswordFile reads the password from the first line of path, warning when
+// the file is readable by other users.
+func readPasswordFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("password file %s is a directory", path)
+	}
+	if info.Mode().Perm()&0o004 != 0 {
+		utils.PrintWarning(fmt.Sprintf("password file %s is readable by other users; restrict it with 'chmod 600 %s'", path, path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+	password, _, _ := strings.Cut(string(data), "\n")
+	password = strings.TrimRight(password, "\r")
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", path)
+	}
+	return password, nil
+}
+
+// refreshCmd exchanges the active context's still-valid token for a new one.
+var refreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh the auth token for the active context without re-entering credentials",
+	Long: `Exchange the active context's token for a fresh one with a new expiry, so a
+session nearing expiry can be extended without entering the password again.
+
+The current token must still be valid; once it has expired, PocketBase rejects
+the refresh and you need to run 'pb auth' instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		if ctx.PocketBase.AuthToken == "" {
+			return fmt.Errorf("context '%s' is not authenticated. Run 'pb auth' to authenticate", ctx.Name)
+		}
+		if !pocketbase.IsAuthValid(ctx) {
+			return fmt.Errorf("the token for context '%s' has expired and can't be refreshed. Run 'pb auth' to re-authenticate", ctx.Name)
+		}
+
+		collection := ctx.PocketBase.AuthCollection
+		if collection == "" {
+			collection = config.AuthCollectionUsers
+		}
+
+		utils.PrintDebug(fmt.Sprintf("Refreshing auth token with collection '%s'", collection))
+
+		client := pocketbase.NewClientFromContext(ctx)
+		authResp, err := client.RefreshAuth(collection)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("token refresh failed")
+			}
+			return fmt.Errorf("token refresh failed: %w", err)
+		}
+
+		if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp); err != nil {
+			return fmt.Errorf("failed to update context: %w", err)
+		}
+		if err := configManager.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to save refreshed token: %w", err)
+		}
+
+		message := fmt.Sprintf("Refreshed auth token for context '%s'", ctx.Name)
+		if ctx.PocketBase.AuthExpires != nil {
+			message += fmt.Sprintf(" (expires %s)", ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05 MST"))
+		}
+		utils.PrintSuccess(message)
+		return nil
+	},
+}
+
 // logoutCmd clears the stored auth token for the active context.
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
@@ -286,81 +451,102 @@ var logoutCmd = &cobra.Command{
 	},
 }
 
+// authStatus is the authentication state of a context, as shown by 'auth status'.
+type authStatus struct {
+	Context          string     `json:"context" yaml:"context"`
+	URL              string     `json:"url" yaml:"url"`
+	Authenticated    bool       `json:"authenticated" yaml:"authenticated"`
+	Collection       string     `json:"collection,omitempty" yaml:"collection,omitempty"`
+	Identity         string     `json:"identity,omitempty" yaml:"identity,omitempty"`
+	Valid            bool       `json:"valid" yaml:"valid"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	ExpiresInSeconds *int       `json:"expires_in_seconds,omitempty" yaml:"expires_in_seconds,omitempty"`
+}
+
+// newAuthStatus summarizes ctx's authentication at now. ExpiresInSeconds is 0
+// once the token has expired and absent when the token has no known expiry.
+func newAuthStatus(ctx *config.Context, now time.Time) authStatus {
+	status := authStatus{
+		Context:       ctx.Name,
+		URL:           ctx.PocketBase.URL,
+		Authenticated: ctx.PocketBase.AuthToken != "",
+	}
+	if !status.Authenticated {
+		return status
+	}
+
+	status.Collection = ctx.PocketBase.AuthCollection
+	if status.Collection == "" {
+		status.Collection = config.AuthCollectionUsers
+	}
+	status.Identity = pocketbase.Record(ctx.PocketBase.AuthRecord).GetDisplayName()
+	status.Valid = pocketbase.IsAuthValid(ctx)
+
+	if expires := ctx.PocketBase.AuthExpires; expires != nil {
+		status.ExpiresAt = expires
+		remaining := max(0, int(expires.Sub(now)/time.Second))
+		status.ExpiresInSeconds = &remaining
+	}
+	return status
+}
+
 // statusCmd reports the authentication state for the active context.
 var statusCmd = &cobra.Command{
 	Use:     "status",
 	Aliases: []string{"whoami"},
 	Short:   "Show authentication status for the active context",
-	Args:    cobra.NoArgs,
+	Long: `Show whether the active context is authenticated, as whom, and how long the
+token remains valid.
+
+In json or yaml output (the global --output, or output_format in the config file)
+the status is printed as an object, so scripts can branch on it, e.g.
+{"valid": true, "expires_in_seconds": 3600, ...}. Other formats print a summary.
+
+Examples:
+  pb auth status -o table
+  pb auth status -o json | jq -e .valid`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, err := validateActiveContext()
 		if err != nil {
 			return err
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		fmt.Printf("Context:    %s\n", cyan(ctx.Name))
-		fmt.Printf("URL:        %s\n", ctx.PocketBase.URL)
+		status := newAuthStatus(ctx, time.Now())
 
-		if ctx.PocketBase.AuthToken == "" {
-			fmt.Printf("Status:     not authenticated (run 'pb auth')\n")
-			return nil
+		switch config.Global.OutputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML:
+			return utils.OutputData(status, config.Global.OutputFormat)
 		}
 
-		collection := ctx.PocketBase.AuthCollection
-		if collection == "" {
-			collection = config.AuthCollectionUsers
+		w := utils.DataOutput()
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Fprintf(w, "Context:    %s\n", cyan(status.Context))
+		fmt.Fprintf(w, "URL:        %s\n", status.URL)
+
+		if !status.Authenticated {
+			fmt.Fprintf(w, "Status:     not authenticated (run 'pb auth')\n")
+			return nil
 		}
-		fmt.Printf("Collection: %s\n", pocketbase.GetCollectionDisplayName(collection))
 
-		if identity := getRecordDisplayName(ctx.PocketBase.AuthRecord, collection); identity != "" {
-			fmt.Printf("Identity:   %s\n", identity)
+		fmt.Fprintf(w, "Collection: %s\n", pocketbase.GetCollectionDisplayName(status.Collection))
+		if status.Identity != "" {
+			fmt.Fprintf(w, "Identity:   %s\n", status.Identity)
 		}
 
-		if pocketbase.IsAuthValid(ctx) {
-			fmt.Printf("Status:     %s\n", color.New(color.FgGreen).Sprint("valid"))
+		if status.Valid {
+			fmt.Fprintf(w, "Status:     %s\n", color.New(color.FgGreen).Sprint("valid"))
 		} else {
-			fmt.Printf("Status:     %s\n", color.New(color.FgYellow).Sprint("expired (run 'pb auth')"))
+			fmt.Fprintf(w, "Status:     %s\n", color.New(color.FgYellow).Sprint("expired (run 'pb auth')"))
 		}
-		if ctx.PocketBase.AuthExpires != nil {
-			fmt.Printf("Expires:    %s\n", ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05 MST"))
+		if status.ExpiresAt != nil {
+			expires := status.ExpiresAt.Format("2006-01-02 15:04:05 MST")
+			if status.Valid {
+				expires += fmt.Sprintf(" (in %s)", utils.FormatDuration(time.Duration(*status.ExpiresInSeconds)*time.Second))
+			}
+			fmt.Fprintf(w, "Expires:    %s\n", expires)
 		}
 
 		return nil
 	},
 }
-
-// getRecordDisplayName returns a human-readable display name for a record
-func getRecordDisplayName(record map[string]interface{}, collection string) string {
-	nameFields := []string{"name", "full_name", "display_name", "title"}
-	for _, field := range nameFields {
-		if name, ok := record[field].(string); ok && name != "" {
-			return name
-		}
-	}
-
-	if firstName, ok := record["first_name"].(string); ok {
-		if lastName, ok := record["last_name"].(string); ok {
-			if firstName != "" && lastName != "" {
-				return firstName + " " + lastName
-			} else if firstName != "" {
-				return firstName
-			} else if lastName != "" {
-				return lastName
-			}
-		}
-	}
-
-	if username, ok := record["username"].(string); ok && username != "" {
-		return username
-	}
-
-	if email, ok := record["email"].(string); ok && email != "" {
-		return email
-	}
-	if id, ok := record["id"].(string); ok {
-		return id
-	}
-
-	return ""
-}
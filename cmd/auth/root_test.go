@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+)
+
+// TestNewAuthStatus checks the fields scripts branch on in 'auth status -o json'.
+func TestNewAuthStatus(t *testing.T) {
+	now := time.Now()
+	ctx := &config.Context{Name: "dev", PocketBase: config.PocketBaseConfig{URL: "http://localhost:8090"}}
+
+	status := newAuthStatus(ctx, now)
+	assert.False(t, status.Authenticated)
+	assert.False(t, status.Valid)
+	assert.Nil(t, status.ExpiresInSeconds)
+
+	expires := now.Add(90 * time.Second)
+	ctx.PocketBase.AuthToken = "token"
+	ctx.PocketBase.AuthExpires = &expires
+	ctx.PocketBase.AuthRecord = map[string]interface{}{"id": "u1", "email": "ada@example.com"}
+
+	status = newAuthStatus(ctx, now)
+	assert.True(t, status.Valid)
+	assert.Equal(t, config.AuthCollectionUsers, status.Collection)
+	assert.Equal(t, "ada@example.com", status.Identity)
+	require.NotNil(t, status.ExpiresInSeconds)
+	assert.Equal(t, 90, *status.ExpiresInSeconds)
+
+	expired := now.Add(-time.Hour)
+	ctx.PocketBase.AuthExpires = &expired
+	status = newAuthStatus(ctx, now)
+	assert.False(t, status.Valid)
+	require.NotNil(t, status.ExpiresInSeconds)
+	assert.Equal(t, 0, *status.ExpiresInSeconds)
+}
+
+// TestReadPasswordFile checks that only the first line is used and that empty
+// or missing files are rejected.
+func TestReadPasswordFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(path, []byte("s3cret pass\r\n"), 0o600))
+	password, err := readPasswordFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret pass", password)
+
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+	_, err = readPasswordFile(empty)
+	assert.Error(t, err)
+
+	_, err = readPasswordFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -22,10 +22,15 @@ If no output path is specified, the backup will be downloaded to:
 If only a directory is specified, the backup will be saved with
 its original name in that directory.
 
+Data is written to <output>.part and moved into place once the size matches the
+backup. If a download is interrupted, rerunning the same command resumes from the
+.part file when the server supports HTTP ranges, and restarts otherwise.
+
 Examples:
   pb backup download backup_2024_01_15                    # Download to context folder
   pb backup download backup_2024_01_15 ./my-backups/     # Download to specific directory
-  pb backup download backup_2024_01_15 ./backup.zip      # Download with specific filename`,
+  pb backup download backup_2024_01_15 ./backup.zip      # Download with specific filename
+  pb backup download backup_2024_01_15 --progress-interval 5  # Report every 5%`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		backupName := args[0]
@@ -85,21 +90,10 @@ Examples:
 		// Download with progress
 		utils.PrintInfo("Downloading backup...")
 
-		var lastProgress int
-		progressCallback := func(downloaded, total int64) {
-			if total > 0 {
-				progress := int((downloaded * 100) / total)
-				if progress != lastProgress && progress%10 == 0 { // Show every 10%
-					fmt.Printf("  Progress: %d%% (%s / %s)\n",
-						progress,
-						utils.FormatBytes(downloaded),
-						utils.FormatBytes(total))
-					lastProgress = progress
-				}
-			}
-		}
+		progress := utils.NewProgressReporter(os.Stderr, progressIntervalFlag)
 
-		err = client.DownloadBackupWithProgress(backupName, outputPath, progressCallback)
+		err = client.DownloadBackupWithProgress(backupName, outputPath, progress.Update)
+		progress.Finish()
 		if err != nil {
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
@@ -130,3 +124,7 @@ Examples:
 		return nil
 	},
 }
+
+func init() {
+	downloadCmd.Flags().IntVar(&progressIntervalFlag, "progress-interval", utils.DefaultProgressInterval, "Report progress every N percent")
+}
@@ -41,7 +41,7 @@ Examples:
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
 				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
-					fmt.Printf("\nSuggestion: %s\n", suggestion)
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
 				}
 				return fmt.Errorf("failed to list backups")
 			}
@@ -49,8 +49,8 @@ Examples:
 		}
 
 		if len(backups) == 0 {
-			fmt.Println("No backups found.")
-			fmt.Printf("\nCreate your first backup with: %s\n",
+			fmt.Fprintln(utils.DataOutput(), "No backups found.")
+			fmt.Fprintf(utils.DataOutput(), "\nCreate your first backup with: %s\n",
 				color.New(color.FgCyan).Sprint("pb backup create"))
 			return nil
 		}
@@ -72,7 +72,8 @@ Examples:
 
 // displayBackupsTable displays backups in a table format
 func displayBackupsTable(backups pocketbase.BackupsList, ctx *config.Context) error {
-	table := tablewriter.NewWriter(os.Stdout)
+	w := utils.DataOutput()
+	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"NAME", "SIZE", "CREATED", "AGE"})
 	table.SetBorder(false)
 	table.SetHeaderLine(false)
@@ -93,21 +94,21 @@ func displayBackupsTable(backups pocketbase.BackupsList, ctx *config.Context) er
 		})
 	}
 
-	fmt.Printf("Backups for context '%s' (%d total):\n", ctx.Name, len(backups))
+	fmt.Fprintf(w, "Backups for context '%s' (%d total):\n", ctx.Name, len(backups))
 	table.Render()
 
 	// Show helpful commands
-	fmt.Printf("\nUseful commands:\n")
+	fmt.Fprintf(w, "\nUseful commands:\n")
 	if len(backups) > 0 {
 		firstBackup := backups[0].Key
-		fmt.Printf("  Download backup: %s\n",
+		fmt.Fprintf(w, "  Download backup: %s\n",
 			color.New(color.FgCyan).Sprintf("pb backup download %s", firstBackup))
-		fmt.Printf("  Restore from backup: %s\n",
+		fmt.Fprintf(w, "  Restore from backup: %s\n",
 			color.New(color.FgCyan).Sprintf("pb backup restore %s", firstBackup))
-		fmt.Printf("  Delete backup: %s\n",
+		fmt.Fprintf(w, "  Delete backup: %s\n",
 			color.New(color.FgCyan).Sprintf("pb backup delete %s", firstBackup))
 	}
-	fmt.Printf("  Create new backup: %s\n",
+	fmt.Fprintf(w, "  Create new backup: %s\n",
 		color.New(color.FgCyan).Sprint("pb backup create"))
 
 	return nil
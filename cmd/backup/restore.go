@@ -1,8 +1,11 @@
 package backup
 
 import (
+	"archive/zip"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -11,6 +14,11 @@ import (
 	"pb-cli/internal/utils"
 )
 
+var (
+	restoreWaitTimeoutFlag time.Duration
+	restoreDryRunFlag      bool
+)
+
 var restoreCmd = &cobra.Command{
 	Use:   "restore <backup_name>",
 	Short: "Restore from a backup",
@@ -21,11 +29,20 @@ All current data will be lost and cannot be recovered unless you have
 another backup.
 
 Note: Restoring from backups requires admin authentication and will
-restart the PocketBase instance.
+restart the PocketBase instance. After starting the restore, pb polls the health
+endpoint until it sees the server go down and come back, for up to --wait-timeout
+(0 skips the wait).
+
+--dry-run rehearses the restore without starting it: it checks authentication
+and that the backup exists on the server, validates the local copy in the
+context's backup directory if one has been downloaded, and prints what would
+happen. Nothing is changed.
 
 Examples:
+  pb backup restore backup_2024_01_15 --dry-run  # Rehearse without restoring
   pb backup restore backup_2024_01_15      # Restore with confirmation
-  pb backup restore backup_2024_01_15 --force  # Restore without confirmation`,
+  pb backup restore backup_2024_01_15 --force  # Restore without confirmation
+  pb backup restore backup_2024_01_15 --wait-timeout 10m  # Allow a slow restart`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		backupName := args[0]
@@ -52,6 +69,10 @@ Examples:
 			return fmt.Errorf("failed to get backup info: %w", err)
 		}
 
+		if restoreDryRunFlag {
+			return printRestoreDryRun(backup, ctx)
+		}
+
 		// Recommend creating a current backup before restore
 		fmt.Printf("\n%s Consider creating a backup of the current state before proceeding:\n",
 			color.New(color.FgYellow).Sprint("Recommendation:"))
@@ -98,9 +119,24 @@ Examples:
 		fmt.Printf("  Backup date: %s\n", backup.GetFormattedDate())
 		fmt.Printf("  Context: %s\n", cyan(ctx.Name))
 
+		// Wait for the restart instead of leaving the user to guess when it's done.
+		serverBack := false
+		if restoreWaitTimeoutFlag > 0 {
+			utils.PrintInfo(fmt.Sprintf("Waiting up to %s for PocketBase to come back...", restoreWaitTimeoutFlag))
+			waited, err := client.WaitHealthy(restoreWaitTimeoutFlag)
+			if err != nil {
+				utils.PrintWarning(fmt.Sprintf("%v. Check the server, then verify with 'pb health'", err))
+			} else {
+				serverBack = true
+				fmt.Printf("%s PocketBase is back online (after %s)\n", green("✓"), waited.Round(time.Second))
+			}
+		}
+
 		// Important post-restore information
 		fmt.Printf("\n%s Important Notes:\n", yellow("⚠"))
-		fmt.Printf("  • PocketBase may be restarting - wait a moment before making requests\n")
+		if !serverBack {
+			fmt.Printf("  • PocketBase may be restarting - wait a moment before making requests\n")
+		}
 		fmt.Printf("  • Your authentication token may be invalidated\n")
 		fmt.Printf("  • All data has been replaced with the backup data\n")
 
@@ -117,6 +153,76 @@ Examples:
 	},
 }
 
+func init() {
+	restoreCmd.Flags().DurationVar(&restoreWaitTimeoutFlag, "wait-timeout", 2*time.Minute, "How long to wait for PocketBase to become healthy after the restore (0 to skip)")
+	restoreCmd.Flags().BoolVar(&restoreDryRunFlag, "dry-run", false, "Validate the backup and show what would happen without restoring")
+}
+
+// printRestoreDryRun reports what a restore of backup would do. The backup has
+// already been fetched with the active credentials, so auth and the superuser
+// requirement are known to hold. A downloaded copy, if present, is validated.
+func printRestoreDryRun(backup *pocketbase.Backup, ctx *config.Context) error {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	cyan := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("\n%s No changes will be made.\n", yellow("DRY RUN:"))
+	fmt.Printf("\nChecks:\n")
+	fmt.Printf("  %s Authenticated as a superuser on context %s\n", green("✓"), cyan(ctx.Name))
+	fmt.Printf("  %s Backup '%s' exists on the server (%s, created %s)\n",
+		green("✓"), backup.Key, backup.GetHumanSize(), backup.GetFormattedDate())
+
+	localPath := filepath.Join(getBackupDir(ctx), backup.Key)
+	valid := true
+	if _, err := os.Stat(localPath); err != nil {
+		fmt.Printf("  - No local copy at %s; archive contents not checked\n", localPath)
+	} else if entries, err := validateBackupArchive(localPath); err != nil {
+		valid = false
+		fmt.Printf("  %s Local copy %s: %v\n", red("✗"), localPath, err)
+	} else {
+		fmt.Printf("  %s Local copy %s is a valid archive (%d entries)\n", green("✓"), localPath, entries)
+	}
+
+	fmt.Printf("\nA restore would:\n")
+	fmt.Printf("  • Replace all data on %s with the contents of '%s'\n", ctx.PocketBase.URL, backup.Key)
+	if restoreWaitTimeoutFlag > 0 {
+		fmt.Printf("  • Restart PocketBase and wait up to %s for it to come back\n", restoreWaitTimeoutFlag)
+	} else {
+		fmt.Printf("  • Restart PocketBase\n")
+	}
+	fmt.Printf("  • Likely invalidate the current authentication token\n")
+	if !forceFlag {
+		fmt.Printf("  • Ask you to type 'restore' to confirm first\n")
+	}
+
+	if !valid {
+		return fmt.Errorf("dry run found problems with the local backup copy")
+	}
+	fmt.Printf("\nRun without --dry-run to restore: %s\n", cyan(fmt.Sprintf("pb backup restore %s", backup.Key)))
+	return nil
+}
+
+// validateBackupArchive checks that path is a readable zip archive holding a
+// PocketBase data directory, and returns its number of entries.
+func validateBackupArchive(path string) (int, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return 0, fmt.Errorf("not a valid zip archive: %w", err)
+	}
+	defer archive.Close()
+
+	if len(archive.File) == 0 {
+		return 0, fmt.Errorf("archive is empty")
+	}
+	for _, file := range archive.File {
+		if filepath.Base(file.Name) == "data.db" {
+			return len(archive.File), nil
+		}
+	}
+	return 0, fmt.Errorf("archive has no data.db; it does not look like a PocketBase backup")
+}
+
 // confirmRestore shows restore details and requires the user to type "restore"
 // to confirm. It returns true only when the user types the exact word.
 func confirmRestore(backup *pocketbase.Backup, ctx *config.Context) (bool, error) {
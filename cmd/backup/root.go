@@ -12,6 +12,9 @@ var (
 	outputFlag string
 	forceFlag  bool
 	nameFlag   string
+
+	// progressIntervalFlag is the percentage step for download/upload progress.
+	progressIntervalFlag int
 )
 
 // BackupCmd represents the backup command
@@ -103,6 +106,10 @@ func validateActiveContext() (*config.Context, error) {
 		return nil, fmt.Errorf("authentication has expired. Run 'pb auth' to re-authenticate")
 	}
 
+	if err := pocketbase.RequireSuperuser(ctx); err != nil {
+		return nil, err
+	}
+
 	return ctx, nil
 }
 
@@ -0,0 +1,58 @@
+package backup
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+)
+
+// TestGetOutputFormat checks that backup commands fall back to the global output
+// format (set from the root -o or the config file) when their own flag is unset.
+func TestGetOutputFormat(t *testing.T) {
+	originalGlobal, originalFlag := config.Global.OutputFormat, outputFlag
+	defer func() { config.Global.OutputFormat, outputFlag = originalGlobal, originalFlag }()
+
+	config.Global.OutputFormat = config.OutputFormatJSON
+	outputFlag = ""
+	assert.Equal(t, config.OutputFormatJSON, getOutputFormat())
+
+	outputFlag = config.OutputFormatTable
+	assert.Equal(t, config.OutputFormatTable, getOutputFormat())
+}
+
+func TestValidateBackupArchive(t *testing.T) {
+	dir := t.TempDir()
+	writeZip := func(name string, entries ...string) string {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		w := zip.NewWriter(f)
+		for _, entry := range entries {
+			_, err := w.Create(entry)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		require.NoError(t, f.Close())
+		return path
+	}
+
+	entries, err := validateBackupArchive(writeZip("good.zip", "data.db", "auxiliary.db", "storage/"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, entries)
+
+	_, err = validateBackupArchive(writeZip("other.zip", "notes.txt"))
+	assert.ErrorContains(t, err, "data.db")
+
+	_, err = validateBackupArchive(writeZip("empty.zip"))
+	assert.ErrorContains(t, err, "empty")
+
+	notZip := filepath.Join(dir, "bad.zip")
+	require.NoError(t, os.WriteFile(notZip, []byte("not a zip"), 0o600))
+	_, err = validateBackupArchive(notZip)
+	assert.ErrorContains(t, err, "not a valid zip")
+}
@@ -62,21 +62,10 @@ Examples:
 		// Upload the backup
 		utils.PrintInfo("Uploading backup...")
 
-		var lastProgress int
-		progressCallback := func(uploaded, total int64) {
-			if total > 0 {
-				progress := int((uploaded * 100) / total)
-				if progress != lastProgress && progress%10 == 0 { // Show every 10%
-					fmt.Printf("  Progress: %d%% (%s / %s)\n",
-						progress,
-						utils.FormatBytes(uploaded),
-						utils.FormatBytes(total))
-					lastProgress = progress
-				}
-			}
-		}
+		progress := utils.NewProgressReporter(os.Stderr, progressIntervalFlag)
 
-		backup, err := client.UploadBackup(filePath, nameFlag, progressCallback)
+		backup, err := client.UploadBackup(filePath, nameFlag, progress.Update)
+		progress.Finish()
 		if err != nil {
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
@@ -126,4 +115,5 @@ Examples:
 
 func init() {
 	uploadCmd.Flags().StringVarP(&nameFlag, "name", "n", "", "Custom backup name (PocketBase will use filename if not specified)")
+	uploadCmd.Flags().IntVar(&progressIntervalFlag, "progress-interval", utils.DefaultProgressInterval, "Report progress every N percent")
 }
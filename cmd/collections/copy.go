@@ -0,0 +1,168 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	copyToContextFlag string
+	copyForceFlag     bool
+	copyQuietFlag     bool
+)
+
+var copyCmd = &cobra.Command{
+	Use:   "copy <collection> <id> --to-context <name>",
+	Short: "Copy a record to another context",
+	Long: `Copy a record from the active context to the same collection in another context.
+
+The record is read from the active context and created in the target context
+with its id, created, and updated fields dropped, so the target assigns new ones.
+File fields are not copied since uploaded files belong to the source instance;
+they can only be detected when the active context can read the collection schema.
+
+The target context must already be authenticated ('pb context select <name>' and
+'pb auth', then switch back).
+
+Examples:
+  pb collections copy settings set_123 --to-context prod
+  pb collections copy posts post_123 --to-context staging -o json --quiet`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCopyRecord(args[0], args[1], false)
+	},
+}
+
+var moveCmd = &cobra.Command{
+	Use:   "move <collection> <id> --to-context <name>",
+	Short: "Move a record to another context",
+	Long: `Move a record from the active context to the same collection in another context.
+
+This works like 'copy', then deletes the source record. The source is only deleted
+after the record was created in the target context, and only after confirmation
+unless --force is given. If the deletion is declined or fails, the copy in the
+target context is kept.
+
+Examples:
+  pb collections move settings set_123 --to-context prod
+  pb collections move posts post_123 --to-context archive --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCopyRecord(args[0], args[1], true)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{copyCmd, moveCmd} {
+		c.Flags().StringVar(&copyToContextFlag, "to-context", "", "Context to create the record in (required)")
+		c.Flags().BoolVarP(&copyQuietFlag, "quiet", "q", false, "Suppress the success summary; print only the new record")
+		c.MarkFlagRequired("to-context")
+	}
+	moveCmd.Flags().BoolVarP(&copyForceFlag, "force", "f", false, "Delete the source record without confirmation")
+}
+
+// runCopyRecord copies recordID from the active context to copyToContextFlag and,
+// when move is set, deletes the source once the copy exists.
+func runCopyRecord(collection, recordID string, move bool) error {
+	sourceCtx, err := validateActiveContext()
+	if err != nil {
+		return err
+	}
+	if copyToContextFlag == sourceCtx.Name {
+		return fmt.Errorf("--to-context must differ from the active context '%s'", sourceCtx.Name)
+	}
+
+	targetCtx, err := validateNamedContext(copyToContextFlag)
+	if err != nil {
+		return err
+	}
+
+	source := createPocketBaseClient(sourceCtx)
+	if collection, err = resolveCollectionName(source, collection); err != nil {
+		return err
+	}
+
+	data, err := cloneRecordData(source, collection, recordID)
+	if err != nil {
+		return err
+	}
+	if err := validateCreateData(data, collection); err != nil {
+		return fmt.Errorf("cannot copy record: %w", err)
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Creating copy of '%s' in collection '%s' of context '%s'", recordID, collection, targetCtx.Name))
+
+	target := createPocketBaseClient(targetCtx)
+	record, err := target.CreateRecord(collection, data, nil)
+	if err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+				fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+			}
+			return fmt.Errorf("failed to create record in context '%s'", targetCtx.Name)
+		}
+		return fmt.Errorf("failed to create record in context '%s': %w", targetCtx.Name, err)
+	}
+
+	newID := pocketbase.Record(record).GetID()
+	green := color.New(color.FgGreen).SprintFunc()
+	if !copyQuietFlag {
+		fmt.Fprintf(os.Stderr, "%s Record copied to context '%s'\n", green("✓"), targetCtx.Name)
+		fmt.Fprintf(os.Stderr, "  Source ID: %s\n", recordID)
+		fmt.Fprintf(os.Stderr, "  New ID: %s\n", newID)
+		fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
+	}
+
+	if move {
+		deleted, err := deleteMovedRecord(source, sourceCtx.Name, collection, recordID)
+		if err != nil {
+			return err
+		}
+		if deleted && !copyQuietFlag {
+			fmt.Fprintf(os.Stderr, "%s Source record deleted from context '%s'\n", green("✓"), sourceCtx.Name)
+		}
+	}
+
+	if !copyQuietFlag {
+		fmt.Fprintf(os.Stderr, "\nNew Record:\n")
+	}
+
+	outputFormat := getOutputFormat()
+	switch outputFormat {
+	case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatTable, config.OutputFormatID:
+		return utils.OutputData(record, outputFormat)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+// deleteMovedRecord deletes the source of a move after confirmation. It reports
+// whether the record was deleted; declining is not an error.
+func deleteMovedRecord(client *pocketbase.Client, contextName, collection, recordID string) (bool, error) {
+	if !copyForceFlag {
+		confirmed, err := utils.Confirm(fmt.Sprintf("Delete source record '%s' from context '%s'? (y/N): ", recordID, contextName))
+		if err != nil {
+			return false, err
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, "Source record kept; the copy remains in the target context.")
+			return false, nil
+		}
+	}
+
+	if err := client.DeleteRecord(collection, recordID); err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			return false, fmt.Errorf("record was copied but the source could not be deleted")
+		}
+		return false, fmt.Errorf("record was copied but the source could not be deleted: %w", err)
+	}
+	return true, nil
+}
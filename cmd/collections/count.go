@@ -0,0 +1,233 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	countFilterFlag    string
+	countFilterValues  []string
+	countGroupByFlag   string
+	countMaxGroupsFlag int
+)
+
+// defaultMaxGroups caps how many distinct values count --group-by tracks.
+const defaultMaxGroups = 1000
+
+var countCmd = &cobra.Command{
+	Use:   "count <collection>",
+	Short: "Count records in a collection",
+	Long: `Print the number of records in a collection, optionally matching a filter.
+
+Only a single record is requested; the count comes from the server's total, so
+large collections are counted without downloading their records.
+
+Output is the bare number for table output and {"count": N} for json/yaml.
+
+With --group-by, records are counted per distinct value of a field instead. Only
+that field is fetched, page by page, and the result is a map of value to count
+for json/yaml or a table sorted by count. Array values (multi-selects, multiple
+relations) are grouped by the whole array. At most --max-groups distinct values
+are tracked; records with other values are reported in a warning.
+
+Examples:
+  pb collections count posts
+  pb collections count posts --filter 'published=true'
+  pb collections count users --filter-value "name=O'Brien"
+  pb c count users -o json
+  pb collections count orders --group-by status
+  pb c count orders --group-by status --filter 'created >= "2024-01-01"' -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		client := createPocketBaseClient(ctx)
+		collection := args[0]
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		filter, err := withFilterValues(countFilterFlag, countFilterValues)
+		if err != nil {
+			return err
+		}
+		if countGroupByFlag != "" {
+			return runGroupedCount(client, collection, filter, countGroupByFlag, countMaxGroupsFlag)
+		}
+
+		options := &pocketbase.ListOptions{
+			Page:    1,
+			PerPage: 1,
+			Filter:  filter,
+			Fields:  []string{"id"},
+		}
+
+		utils.PrintDebug(fmt.Sprintf("Counting records in collection '%s' (filter='%s')", collection, options.Filter))
+
+		result, err := client.ListRecords(collection, options)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("failed to count records")
+			}
+			return fmt.Errorf("failed to count records: %w", err)
+		}
+
+		switch outputFormat := getOutputFormat(); outputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML:
+			return utils.OutputData(map[string]int{"count": result.TotalItems}, outputFormat)
+		case config.OutputFormatTable:
+			_, err := fmt.Fprintln(utils.DataOutput(), result.TotalItems)
+			return err
+		default:
+			return fmt.Errorf("unsupported output format: %s", outputFormat)
+		}
+	},
+}
+
+func init() {
+	countCmd.Flags().StringVar(&countFilterFlag, "filter", "", "Count only records matching this filter expression")
+	countCmd.Flags().StringArrayVar(&countFilterValues, "filter-value", nil, "Count only records where field equals value exactly (field=value, repeatable; quotes are escaped)")
+	countCmd.Flags().StringVar(&countGroupByFlag, "group-by", "", "Count records per distinct value of this field")
+	countCmd.Flags().IntVar(&countMaxGroupsFlag, "max-groups", defaultMaxGroups, "With --group-by, the most distinct values to track")
+}
+
+// groupCounts is the result of counting records per value of a field.
+type groupCounts struct {
+	Counts map[string]int
+	// Other is the number of records whose value was past the group cap.
+	Other int
+	// Found reports whether any record had the field at all.
+	Found bool
+}
+
+// runGroupedCount counts the records of collection matching filter per value of
+// field and prints the counts.
+func runGroupedCount(client *pocketbase.Client, collection, filter, field string, maxGroups int) error {
+	if maxGroups < 1 {
+		return fmt.Errorf("--max-groups must be at least 1")
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Counting records in collection '%s' by '%s' (filter='%s')", collection, field, filter))
+
+	groups := &groupCounts{Counts: make(map[string]int)}
+	options := &pocketbase.ListOptions{Filter: filter, Fields: []string{field}}
+	err := client.EachRecordPage(collection, options, func(page *pocketbase.RecordsList) error {
+		for _, item := range page.Items {
+			groups.add(item, field, maxGroups)
+		}
+		return nil
+	})
+	if err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+				fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+			}
+			return fmt.Errorf("failed to count records")
+		}
+		return fmt.Errorf("failed to count records: %w", err)
+	}
+
+	if len(groups.Counts) > 0 && !groups.Found {
+		return fmt.Errorf("field '%s' not found in collection '%s'", field, collection)
+	}
+	if groups.Other > 0 {
+		utils.PrintWarning(fmt.Sprintf("'%s' has more than %d distinct values; %d record(s) with other values are not counted (raise --max-groups)",
+			field, maxGroups, groups.Other))
+	}
+
+	switch outputFormat := getOutputFormat(); outputFormat {
+	case config.OutputFormatJSON, config.OutputFormatYAML:
+		return utils.OutputData(groups.Counts, outputFormat)
+	case config.OutputFormatTable:
+		if len(groups.Counts) == 0 {
+			_, err := fmt.Fprintln(utils.DataOutput(), "No data found.")
+			return err
+		}
+		table := tablewriter.NewWriter(utils.DataOutput())
+		table.SetHeader([]string{strings.ToUpper(field), "COUNT"})
+		table.SetBorder(false)
+		table.SetHeaderLine(false)
+		table.SetRowSeparator("")
+		table.SetCenterSeparator("")
+		table.SetColumnSeparator("  ")
+		table.SetAutoFormatHeaders(false)
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		for _, value := range groups.sorted() {
+			label := value
+			if label == "" {
+				label = "(empty)"
+			}
+			table.Append([]string{label, strconv.Itoa(groups.Counts[value])})
+		}
+		table.Render()
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+}
+
+// add counts record under its value of field. Once maxGroups values are tracked,
+// records with a new value are only counted in Other.
+func (g *groupCounts) add(record map[string]interface{}, field string, maxGroups int) {
+	value, ok := record[field]
+	if ok {
+		g.Found = true
+	}
+	key := groupKey(value)
+	if _, tracked := g.Counts[key]; !tracked && len(g.Counts) >= maxGroups {
+		g.Other++
+		return
+	}
+	g.Counts[key]++
+}
+
+// sorted returns the counted values, largest count first and ties in value order.
+func (g *groupCounts) sorted() []string {
+	values := make([]string, 0, len(g.Counts))
+	for value := range g.Counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if g.Counts[values[i]] != g.Counts[values[j]] {
+			return g.Counts[values[i]] > g.Counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+	return values
+}
+
+// groupKey renders a field value as a group name: strings as-is, missing values
+// as "", and everything else as JSON (1.5, true, ["a","b"]).
+func groupKey(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
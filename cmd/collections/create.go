@@ -3,6 +3,7 @@ package collections
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -11,7 +12,19 @@ import (
 	"pb-cli/internal/utils"
 )
 
-var createFileFlag string
+var (
+	createFileFlag            string
+	createUpsertKeyFlag       string
+	createFromRecordFlag      string
+	createIDFlag              string
+	createQuietFlag           bool
+	createAllowLargeFlag      bool
+	createContinueOnErrorFlag bool
+	createSetFlag             []string
+	createSetStringFlag       []string
+	createIdempotencyKeyFlag  string
+	createExpandFlag          []string
+)
 
 var createCmd = &cobra.Command{
 	Use:   "create <collection> [json_data]",
@@ -21,12 +34,56 @@ var createCmd = &cobra.Command{
 Data can be provided as:
   1. A JSON string argument
   2. A file via --file flag
-  3. Piped from stdin
+  3. Piped from stdin (only read when no --set or --set-string is given)
+
+A JSON array of objects creates one record per element, reporting each result.
+Creation stops at the first failure unless --continue-on-error is given; with any
+failures a summary of the succeeded indices and of each failure is printed (as a
+JSON object on stderr when -o json is given) and the command exits non-zero. The created records are printed as an array either way.
+
+With --upsert-key, an existing record whose key field matches the value in the
+data is updated instead of creating a duplicate. This makes re-running an
+import idempotent.
+
+With --from-record, an existing record is copied: its id, created, and updated
+fields are dropped and any JSON data given is applied on top as overrides. File
+fields are not copied (uploaded files belong to the original record); they can
+only be detected when the active context can read the collection schema.
+
+Use --set field=value (repeatable) to give fields without writing JSON. Values
+are typed like JSON: numbers, true/false, and null keep their type, and anything
+that isn't valid JSON is a string. --set-string field=value always sends a
+string. With JSON data as well, --set values are applied on top of it (to every
+record of an array).
+
+With --id, the record is created with the given ID instead of a server-generated
+one (PocketBase IDs are 15 lowercase letters or digits). This preserves IDs when
+importing from another system. An "id" field in the JSON data is still rejected.
+
+With --idempotency-key, the record is created with an ID derived from the key
+(suffixed with -<index> for the records of an array), or with --id if given.
+After a timeout or a 429/502/503/504 the CLI looks that ID up before retrying,
+so a create that reached the server is not made twice, and rerunning with the
+same key returns the record created before. Without a value a random key is
+generated and printed; keep it to rerun safely.
+
+With --expand, the printed record includes the named relations expanded, as
+with 'get --expand', without a separate request.
 
 Examples:
   pb collections create posts '{"title":"My Post","content":"Hello world"}'
   pb collections create posts --file post.json
   cat post.json | pb collections create posts
+  pb collections create posts --file post.json --upsert-key slug
+  pb collections create posts '[{"title":"One"},{"title":"Two"}]'
+  pb collections create posts --file posts.json --continue-on-error
+  pb collections create posts --from-record post_123 '{"title":"Copy of post"}'
+  pb collections create posts '{"title":"Hi"}' -o json --quiet | jq -r .id
+  pb collections create posts --id abc123def456ghi '{"title":"Imported"}'
+  pb collections create posts --set title="Quick note" --set published=true --set views=0
+  pb collections create products --file base.json --set-string sku=00042
+  pb collections create comments '{"post":"post_123","body":"Nice"}' --expand post
+  pb collections create orders --file orders.json --idempotency-key import-2024-06-01
   pb c create posts '{"title":"New"}'`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -36,25 +93,114 @@ Examples:
 			jsonData = args[1]
 		}
 
-		ctx, err := validateActiveContext()
+		if createIDFlag != "" {
+			if createUpsertKeyFlag != "" {
+				return fmt.Errorf("--id cannot be combined with --upsert-key")
+			}
+			if err := validateCustomRecordID(createIDFlag); err != nil {
+				return fmt.Errorf("invalid --id: %w", err)
+			}
+		}
+
+		idempotencyKey := createIdempotencyKeyFlag
+		if idempotencyKey != "" && createUpsertKeyFlag != "" {
+			return fmt.Errorf("--idempotency-key cannot be combined with --upsert-key, which is already safe to repeat")
+		}
+		if idempotencyKey == idempotencyKeyAuto {
+			generated, err := pocketbase.NewIdempotencyKey()
+			if err != nil {
+				return err
+			}
+			idempotencyKey = generated
+			if !createQuietFlag {
+				fmt.Fprintf(os.Stderr, "Idempotency key: %s\n", idempotencyKey)
+			}
+		}
+
+		sets, err := parseSetFlags(createSetFlag, createSetStringFlag)
 		if err != nil {
 			return err
 		}
+		hasJSONInput := createHasJSONInput(jsonData, createFileFlag, sets)
+		if createFileFlag != "" {
+			if err := utils.ValidateFileExists(createFileFlag); err != nil {
+				return fmt.Errorf("invalid --file: %w", err)
+			}
+		}
 
-		data, err := parseJSONInput(jsonData, createFileFlag)
+		ctx, err := validateActiveContext()
 		if err != nil {
-			return fmt.Errorf("invalid JSON input: %w", err)
+			return err
+		}
+
+		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		var data map[string]interface{}
+		if createFromRecordFlag != "" {
+			data, err = cloneRecordData(client, collection, createFromRecordFlag)
+			if err != nil {
+				return err
+			}
+
+			// Overrides are optional when cloning.
+			if hasJSONInput {
+				overrides, err := parseJSONInput(jsonData, createFileFlag)
+				if err != nil {
+					return fmt.Errorf("invalid JSON input: %w", err)
+				}
+				for key, value := range overrides {
+					data[key] = value
+				}
+			}
+			mergeSetValues(data, sets)
+		} else if !hasJSONInput && len(sets) > 0 {
+			data = sets
+		} else {
+			raw, err := readJSONInput(jsonData, createFileFlag)
+			if err != nil {
+				return fmt.Errorf("invalid JSON input: %w", err)
+			}
+			records, isArray, err := parseJSONRecords(raw)
+			if err != nil {
+				return fmt.Errorf("invalid JSON input: %w", err)
+			}
+			for _, record := range records {
+				mergeSetValues(record, sets)
+			}
+			if isArray {
+				if createIDFlag != "" {
+					return fmt.Errorf("--id cannot be used when creating multiple records")
+				}
+				return createRecords(client, collection, records, idempotencyKey)
+			}
+			data = records[0]
 		}
 
 		if err := validateCreateData(data, collection); err != nil {
 			return fmt.Errorf("invalid create data: %w", err)
 		}
+		if err := checkPayloadSize(data, createAllowLargeFlag); err != nil {
+			return err
+		}
 
-		client := createPocketBaseClient(ctx)
+		// Injected after validation: the restricted-field check still rejects an
+		// "id" in the payload itself, so --id is the only way to set one.
+		if createIDFlag != "" {
+			data["id"] = createIDFlag
+		}
 
 		utils.PrintDebug(fmt.Sprintf("Creating record in collection '%s' with data: %+v", collection, data))
 
-		record, err := client.CreateRecord(collection, data)
+		var record map[string]interface{}
+		created := true
+		if createUpsertKeyFlag != "" {
+			record, created, err = client.UpsertRecord(collection, createUpsertKeyFlag, data, createExpandFlag)
+		} else {
+			record, err = client.CreateRecordWithKey(collection, data, idempotencyKey, createExpandFlag)
+		}
 		if err != nil {
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
@@ -69,26 +215,33 @@ Examples:
 			return fmt.Errorf("failed to create record: %w", err)
 		}
 
-		recordID := ""
-		if id, ok := record["id"].(string); ok {
-			recordID = id
+		recordID := pocketbase.Record(record).GetID()
+
+		action := "created"
+		if !created {
+			action = "updated"
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Fprintf(os.Stderr, "%s Record created successfully!\n", green("✓"))
+		if !createQuietFlag {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Fprintf(os.Stderr, "%s Record %s successfully!\n", green("✓"), action)
 
-		if recordID != "" {
-			fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
-			fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
+			if recordID != "" {
+				fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
+				fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
 
-			if name := getRecordDisplayName(record); name != "" {
-				fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
+				if name := pocketbase.Record(record).GetDisplayName(); name != "" {
+					fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
+				}
+				if createUpsertKeyFlag != "" {
+					fmt.Fprintf(os.Stderr, "  Matched on: %s\n", createUpsertKeyFlag)
+				}
 			}
+
+			fmt.Fprintf(os.Stderr, "\n%s Record:\n", utils.TitleCase(action))
 		}
 
 		outputFormat := getOutputFormat()
-
-		fmt.Fprintf(os.Stderr, "\nCreated Record:\n")
 		switch outputFormat {
 		case config.OutputFormatJSON:
 			return utils.OutputData(record, config.OutputFormatJSON)
@@ -96,6 +249,8 @@ Examples:
 			return utils.OutputData(record, config.OutputFormatYAML)
 		case config.OutputFormatTable:
 			return utils.OutputData(record, config.OutputFormatTable)
+		case config.OutputFormatID:
+			return utils.OutputData(record, config.OutputFormatID)
 		default:
 			return fmt.Errorf("unsupported output format: %s", outputFormat)
 		}
@@ -104,4 +259,72 @@ Examples:
 
 func init() {
 	createCmd.Flags().StringVar(&createFileFlag, "file", "", "Path to JSON file containing record data")
+	createCmd.Flags().StringVar(&createFromRecordFlag, "from-record", "", "Copy an existing record by ID; JSON data, if given, overrides its fields")
+	createCmd.Flags().StringVar(&createIDFlag, "id", "", "Create the record with this custom ID (15 chars, a-z and 0-9)")
+	createCmd.Flags().BoolVar(&createContinueOnErrorFlag, "continue-on-error", false, "When creating from a JSON array, keep going after a record fails")
+	createCmd.Flags().BoolVarP(&createQuietFlag, "quiet", "q", false, "Suppress the success summary; print only the record")
+	createCmd.Flags().StringArrayVar(&createSetFlag, "set", nil, "Set a field, typed like JSON (field=value; repeatable)")
+	createCmd.Flags().StringArrayVar(&createSetStringFlag, "set-string", nil, "Set a field to a string value (field=value; repeatable)")
+	createCmd.Flags().BoolVar(&createAllowLargeFlag, "allow-large", false, "Send record data over the large_payload_limit_bytes limit (default 10 MB) instead of refusing it")
+	createCmd.Flags().StringVar(&createUpsertKeyFlag, "upsert-key", "", "Update the existing record whose value for this field matches, instead of creating a duplicate")
+	createCmd.Flags().StringVar(&createIdempotencyKeyFlag, "idempotency-key", "", "Create with an ID derived from this key (generated if no value) so retries and reruns can't duplicate it")
+	createCmd.Flags().Lookup("idempotency-key").NoOptDefVal = idempotencyKeyAuto
+	createCmd.Flags().StringSliceVar(&createExpandFlag, "expand", nil, "Relations to expand in the returned record (comma-separated)")
+}
+
+// createHasJSONInput reports whether create takes JSON from the argument,
+// --file, or stdin. Piped stdin is ignored when --set/--set-string give the
+// data, since it is often the input of an xargs or while-read loop.
+func createHasJSONInput(jsonData, filePath string, sets map[string]interface{}) bool {
+	return jsonData != "" || filePath != "" || (len(sets) == 0 && stdinIsPiped())
+}
+
+// idempotencyKeyAuto is the --idempotency-key value given without an argument;
+// it asks for a generated key.
+const idempotencyKeyAuto = "auto"
+
+// cloneRecordData fetches an existing record and returns its data ready to create a
+// copy: system fields are removed, and so are file fields when the schema is readable.
+func cloneRecordData(client *pocketbase.Client, collection, recordID string) (map[string]interface{}, error) {
+	if err := validateRecordID(recordID); err != nil {
+		return nil, fmt.Errorf("invalid --from-record ID: %w", err)
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Fetching record '%s' from collection '%s' to clone", recordID, collection))
+
+	source, err := client.GetRecord(collection, recordID, nil, nil)
+	if err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			return nil, fmt.Errorf("failed to fetch record to clone")
+		}
+		return nil, fmt.Errorf("failed to fetch record to clone: %w", err)
+	}
+
+	for _, field := range []string{"id", "created", "updated", "collectionId", "collectionName", "expand"} {
+		delete(source, field)
+	}
+
+	// File fields can only be identified from the schema, which is superuser-only.
+	schema, err := client.GetCollectionSchema(collection)
+	if err != nil {
+		utils.PrintDebug(fmt.Sprintf("Could not read schema for '%s': %v", collection, err))
+		utils.PrintWarning("could not read the collection schema; file fields, if any, may fail to copy")
+		return source, nil
+	}
+
+	var skipped []string
+	for _, field := range schema.Fields {
+		if field.Type == "file" {
+			if _, exists := source[field.Name]; exists {
+				delete(source, field.Name)
+				skipped = append(skipped, field.Name)
+			}
+		}
+	}
+	if len(skipped) > 0 {
+		utils.PrintWarning(fmt.Sprintf("file fields are not copied: %s", strings.Join(skipped, ", ")))
+	}
+
+	return source, nil
 }
@@ -0,0 +1,167 @@
+package collections
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+// createRecords creates one record per element of a JSON array input, reporting
+// each result on stderr and printing the created records on stdout. It stops at the
+// first failure unless --continue-on-error is set, and returns an error if any
+// element failed. With an idempotency key, element i is created with the key
+// "<key>-<i>".
+func createRecords(client *pocketbase.Client, collection string, items []map[string]interface{}, idempotencyKey string) error {
+	if getOutputFormat() == config.OutputFormatID {
+		return fmt.Errorf("--output id applies only to a single record; it cannot be used when creating from a JSON array")
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	var records []map[string]interface{}
+	var succeeded []int
+	failed := &utils.MultiError{Limit: utils.DefaultMultiErrorLimit}
+	attempted := 0
+	summary := createSummary{Collection: collection, Total: len(items)}
+
+	for i, data := range items {
+		attempted++
+
+		err := validateCreateData(data, collection)
+		if err == nil {
+			err = checkPayloadSize(data, createAllowLargeFlag)
+		}
+		var record map[string]interface{}
+		created := true
+		if err == nil {
+			utils.PrintDebug(fmt.Sprintf("Creating record %d/%d in collection '%s'", i+1, len(items), collection))
+			if createUpsertKeyFlag != "" {
+				record, created, err = client.UpsertRecord(collection, createUpsertKeyFlag, data, createExpandFlag)
+			} else {
+				record, err = client.CreateRecordWithKey(collection, data, itemIdempotencyKey(idempotencyKey, i), createExpandFlag)
+			}
+		}
+
+		if err != nil {
+			failed.Add(strconv.Itoa(i), err)
+			fmt.Fprintf(os.Stderr, "%s [%d] %v\n", red("✗"), i, err)
+			if !createContinueOnErrorFlag {
+				break
+			}
+			continue
+		}
+
+		succeeded = append(succeeded, i)
+		records = append(records, record)
+		if created {
+			summary.Created++
+		} else {
+			summary.Updated++
+		}
+		if !createQuietFlag {
+			action := "created"
+			if !created {
+				action = "updated"
+			}
+			fmt.Fprintf(os.Stderr, "%s [%d] %s %s\n", green("✓"), i, action, pocketbase.Record(record).GetID())
+		}
+	}
+
+	outputFormat := getOutputFormat()
+	summary.Succeeded = len(succeeded)
+	summary.Failed = failed.Len()
+	summary.Skipped = len(items) - attempted
+	if failed.Len() > 0 || !createQuietFlag {
+		if err := writeCreateSummary(os.Stderr, summary, failureReportFormat()); err != nil {
+			return err
+		}
+	}
+	if failed.Len() > 0 {
+		// The JSON summary already carries these counts.
+		if !isJSONReport(failureReportFormat()) {
+			fmt.Fprintf(os.Stderr, "  Succeeded: %s\n", formatIndices(succeeded))
+			if attempted < len(items) {
+				fmt.Fprintf(os.Stderr, "  Skipped:   %d-%d (stopped at the first failure; use --continue-on-error to keep going)\n", attempted, len(items)-1)
+			}
+		}
+		if err := failed.Write(os.Stderr, failureReportFormat()); err != nil {
+			return err
+		}
+	}
+
+	if len(records) > 0 {
+		switch outputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatTable:
+			if err := utils.OutputData(records, outputFormat); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unsupported output format: %s", outputFormat)
+		}
+	}
+
+	failed.Message = fmt.Sprintf("failed to create %d of %d record(s)", failed.Len(), len(items))
+	return failed.ErrorOrNil()
+}
+
+// createSummary counts the outcome of a batch create for its closing report.
+// Created and Updated split Succeeded when --upsert-key matched existing records.
+type createSummary struct {
+	Collection string `json:"collection"`
+	Total      int    `json:"total"`
+	Succeeded  int    `json:"succeeded"`
+	Created    int    `json:"created"`
+	Updated    int    `json:"updated"`
+	Failed     int    `json:"failed"`
+	Skipped    int    `json:"skipped"`
+}
+
+// writeCreateSummary reports a batch create's totals: as a JSON line for json and
+// ndjson output, otherwise as a sentence with the created/updated split when
+// upserting.
+func writeCreateSummary(w io.Writer, summary createSummary, format string) error {
+	if isJSONReport(format) {
+		return utils.WriteJSONLine(w, summary)
+	}
+	line := fmt.Sprintf("\n%d of %d record(s) succeeded in '%s'", summary.Succeeded, summary.Total, summary.Collection)
+	if createUpsertKeyFlag != "" {
+		line += fmt.Sprintf(" (%d created, %d updated)", summary.Created, summary.Updated)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// isJSONReport reports whether batch reports are rendered as JSON lines, matching
+// utils.MultiError.Write.
+func isJSONReport(format string) bool {
+	return format == config.OutputFormatJSON || format == config.OutputFormatNDJSON
+}
+
+// formatIndices renders array indices as a comma-separated list, or "none".
+func formatIndices(indices []int) string {
+	if len(indices) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = strconv.Itoa(index)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// itemIdempotencyKey returns the idempotency key for element i of a batch create,
+// or "" without a key.
+func itemIdempotencyKey(key string, i int) string {
+	if key == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s-%d", key, i)
+}
@@ -11,36 +11,81 @@ import (
 )
 
 var (
-	forceFlag bool
-	quietFlag bool
+	forceFlag          bool
+	quietFlag          bool
+	deleteFilterFlag   string
+	deleteFilterValues []string
+	deleteLimitFlag    int
+	deleteNoLimitFlag  bool
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete <collection> <id>",
+	Use:   "delete <collection> [id]",
 	Short: "Delete a record from a collection",
-	Long: `Delete a record from a collection by its ID.
+	Long: `Delete a record from a collection by its ID, or every record matching --filter.
 
-By default, prompts for confirmation before deleting.
+By default, prompts for confirmation before deleting. With --filter, the matched
+count is shown and confirmed with y/N; above typed_confirm_threshold records
+(global config, default 50) the collection name must be typed instead. A single
+invocation refuses to delete more than --limit records (default 100); raise
+--limit or pass --no-limit to go beyond it.
 
 Examples:
   pb collections delete posts post_123
   pb collections delete users user_456 --force
-  pb c delete posts post_123 -f -q`,
-	Args: cobra.ExactArgs(2),
+  pb c delete posts post_123 -f -q
+  pb collections delete sessions --filter 'expires < @now'
+  pb collections delete users --filter-value "name=O'Brien"
+  pb collections delete logs --filter 'created < "2024-01-01"' --limit 5000`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		collection := args[0]
-		recordID := args[1]
+
+		filterSet := cmd.Flags().Changed("filter") || cmd.Flags().Changed("filter-value")
+		if filterSet == (len(args) == 2) {
+			return fmt.Errorf("specify either a record ID or --filter/--filter-value")
+		}
+		if !filterSet && (cmd.Flags().Changed("limit") || deleteNoLimitFlag) {
+			return fmt.Errorf("--limit and --no-limit only apply with --filter")
+		}
+		if deleteNoLimitFlag && cmd.Flags().Changed("limit") {
+			return fmt.Errorf("--limit and --no-limit cannot be used together")
+		}
+		if deleteLimitFlag < 1 {
+			return fmt.Errorf("--limit must be at least 1")
+		}
 
 		ctx, err := validateActiveContext()
 		if err != nil {
 			return err
 		}
 
+		if filterSet {
+			client := createPocketBaseClient(ctx)
+			if collection, err = resolveCollectionName(client, collection); err != nil {
+				return err
+			}
+			limit := deleteLimitFlag
+			if deleteNoLimitFlag {
+				limit = 0
+			}
+			filter, err := withFilterValues(deleteFilterFlag, deleteFilterValues)
+			if err != nil {
+				return err
+			}
+			return deleteRecordsByFilter(client, collection, filter, limit)
+		}
+
+		recordID := args[1]
+
 		if err := validateRecordID(recordID); err != nil {
 			return fmt.Errorf("invalid record ID: %w", err)
 		}
 
 		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
 
 		var record map[string]interface{}
 		if !forceFlag {
@@ -88,7 +133,7 @@ Examples:
 			fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
 
 			if record != nil {
-				if name := getRecordDisplayName(record); name != "" {
+				if name := pocketbase.Record(record).GetDisplayName(); name != "" {
 					fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
 				}
 			}
@@ -101,6 +146,10 @@ Examples:
 func init() {
 	deleteCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation prompt")
 	deleteCmd.Flags().BoolVarP(&quietFlag, "quiet", "q", false, "Suppress success messages")
+	deleteCmd.Flags().StringVar(&deleteFilterFlag, "filter", "", "Delete every record matching this filter instead of a single ID")
+	deleteCmd.Flags().StringArrayVar(&deleteFilterValues, "filter-value", nil, "Delete every record where field equals value exactly (field=value, repeatable; quotes are escaped)")
+	deleteCmd.Flags().IntVar(&deleteLimitFlag, "limit", defaultDeleteLimit, "With --filter, refuse to delete more than this many records")
+	deleteCmd.Flags().BoolVar(&deleteNoLimitFlag, "no-limit", false, "With --filter, delete every match however many there are")
 }
 
 // confirmDeletion shows record details and prompts the user to confirm deletion.
@@ -115,7 +164,7 @@ func confirmDeletion(collection, recordID string, record map[string]interface{})
 	fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
 
 	if record != nil {
-		if name := getRecordDisplayName(record); name != "" {
+		if name := pocketbase.Record(record).GetDisplayName(); name != "" {
 			fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
 		}
 
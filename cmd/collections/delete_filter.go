@@ -0,0 +1,132 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+// defaultDeleteLimit caps how many records one 'delete --filter' may remove, so an
+// overly broad filter can't wipe a collection by accident.
+const defaultDeleteLimit = 100
+
+// deleteRecordsByFilter deletes every record in collection matching filter. It
+// refuses when more than limit records match (a limit of 0 means no cap), then
+// asks for confirmation unless --force is set: y/N up to the configured
+// typed_confirm_threshold, the collection name above it.
+func deleteRecordsByFilter(client *pocketbase.Client, collection, filter string, limit int) error {
+	if filter == "" {
+		return fmt.Errorf("--filter cannot be empty; it would match every record")
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Finding records to delete in '%s' (filter='%s')", collection, filter))
+
+	counted, err := client.ListRecords(collection, &pocketbase.ListOptions{Page: 1, PerPage: 1, Filter: filter, Fields: []string{"id"}})
+	if err != nil {
+		return deleteFilterError(err, "find records to delete")
+	}
+	if err := checkDeleteLimit(counted.TotalItems, limit); err != nil {
+		return err
+	}
+	if counted.TotalItems == 0 {
+		fmt.Fprintf(os.Stderr, "No records in '%s' match the filter; nothing to delete.\n", collection)
+		return nil
+	}
+
+	matched, err := client.ListAllRecords(collection, &pocketbase.ListOptions{Filter: filter, Fields: []string{"id"}})
+	if err != nil {
+		return deleteFilterError(err, "find records to delete")
+	}
+	// Records may have been added since the count; the cap applies to what would go.
+	if err := checkDeleteLimit(len(matched.Items), limit); err != nil {
+		return err
+	}
+
+	if !forceFlag {
+		red := color.New(color.FgRed).SprintFunc()
+		yellow := color.New(color.FgYellow).SprintFunc()
+		bold := color.New(color.Bold).SprintFunc()
+
+		fmt.Fprintf(os.Stderr, "%s Records to be deleted:\n", red("⚠"))
+		fmt.Fprintf(os.Stderr, "  Collection: %s\n", bold(collection))
+		fmt.Fprintf(os.Stderr, "  Filter:     %s\n", filter)
+		fmt.Fprintf(os.Stderr, "  Matched:    %d %s\n", len(matched.Items), formatDeleteLimit(limit))
+		fmt.Fprintf(os.Stderr, "\n%s This action cannot be undone.\n", yellow("Warning:"))
+
+		confirmed, err := confirmBulkDelete(collection, len(matched.Items), config.Global.TypedConfirmationThreshold())
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, "Deletion cancelled.")
+			return nil
+		}
+	}
+
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	failed := &utils.MultiError{Limit: utils.DefaultMultiErrorLimit}
+	for _, record := range matched.Records() {
+		id := record.GetID()
+		if err := client.DeleteRecord(collection, id); err != nil {
+			failed.Add(id, err)
+			fmt.Fprintf(os.Stderr, "%s %s %v\n", red("✗"), id, err)
+			continue
+		}
+		if !quietFlag {
+			fmt.Fprintf(os.Stderr, "%s deleted %s\n", green("✓"), id)
+		}
+	}
+
+	if failed.Len() > 0 || !quietFlag {
+		fmt.Fprintf(os.Stderr, "\n%d of %d record(s) deleted from '%s'\n", len(matched.Items)-failed.Len(), len(matched.Items), collection)
+	}
+	if err := failed.Write(os.Stderr, failureReportFormat()); err != nil {
+		return err
+	}
+	failed.Message = fmt.Sprintf("failed to delete %d of %d record(s)", failed.Len(), len(matched.Items))
+	return failed.ErrorOrNil()
+}
+
+// confirmBulkDelete asks before deleting count records: y/N for up to threshold
+// records, and the typed collection name for more.
+func confirmBulkDelete(collection string, count, threshold int) (bool, error) {
+	if count > threshold {
+		return utils.ConfirmWord(fmt.Sprintf("Type '%s' to delete %d record(s): ", collection, count), collection)
+	}
+	return utils.Confirm(fmt.Sprintf("Delete %d record(s)? (y/N): ", count))
+}
+
+// checkDeleteLimit refuses a bulk delete of matched records above limit; a limit
+// of 0 means no cap.
+func checkDeleteLimit(matched, limit int) error {
+	if limit > 0 && matched > limit {
+		return fmt.Errorf("%d records match the filter, more than the limit of %d; narrow the filter, raise --limit, or pass --no-limit", matched, limit)
+	}
+	return nil
+}
+
+// formatDeleteLimit describes the cap for the confirmation summary.
+func formatDeleteLimit(limit int) string {
+	if limit == 0 {
+		return "(no limit)"
+	}
+	return fmt.Sprintf("(limit %d)", limit)
+}
+
+// deleteFilterError reports a failed lookup in the usual friendly form.
+func deleteFilterError(err error, action string) error {
+	if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+		utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+		if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+			fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+		}
+		return fmt.Errorf("failed to %s", action)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
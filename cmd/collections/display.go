@@ -2,69 +2,161 @@ package collections
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"strconv"
 	"strings"
 
+	"github.com/olekukonko/tablewriter"
 	"pb-cli/internal/config"
 	"pb-cli/internal/pocketbase"
 	"pb-cli/internal/utils"
 )
 
 // displayListTable displays the results in a user-friendly table format
-func displayListTable(result *pocketbase.RecordsList, collection string) error {
+func displayListTable(w io.Writer, result *pocketbase.RecordsList, collection string) error {
 	if result == nil || len(result.Items) == 0 {
-		fmt.Printf("No %s found.\n", collection)
+		fmt.Fprintf(w, "No %s found.\n", collection)
 		return nil
 	}
 
 	// Show pagination info
-	fmt.Printf("%s (%d-%d of %d total)\n\n",
+	fmt.Fprintf(w, "%s (%s-%s of %s total)\n\n",
 		utils.TitleCase(collection),
-		((result.Page-1)*result.PerPage)+1,
-		min(result.Page*result.PerPage, result.TotalItems),
-		result.TotalItems)
+		formatCount(((result.Page-1)*result.PerPage)+1),
+		formatCount(min(result.Page*result.PerPage, result.TotalItems)),
+		formatCount(result.TotalItems))
+
+	items := result.Items
+	if truncateIDsFlag {
+		items = withTruncatedIDs(items)
+	}
 
 	// Display table
-	if err := utils.OutputData(result.Items, config.OutputFormatTable); err != nil {
+	if err := utils.OutputTable(w, items, utils.TableOptions{MaxCellLines: maxColLinesFlag}); err != nil {
 		return fmt.Errorf("failed to display table: %w", err)
 	}
 
+	if summaryStatsFlag {
+		displaySummaryStats(w, result.Items)
+	}
+
 	// Show pagination navigation hints
 	if result.TotalPages > 1 {
-		fmt.Printf("\nPagination:\n")
+		fmt.Fprintf(w, "\nPagination:\n")
 		if result.Page > 1 {
-			fmt.Printf("  Previous: --page %d\n", result.Page-1)
+			fmt.Fprintf(w, "  Previous: --page %d\n", result.Page-1)
 		}
 		if result.Page < result.TotalPages {
-			fmt.Printf("  Next: --page %d\n", result.Page+1)
+			fmt.Fprintf(w, "  Next: --page %d\n", result.Page+1)
 		}
-		fmt.Printf("  Page %d of %d (use --page to navigate)\n",
+		fmt.Fprintf(w, "  Page %d of %d (use --page to navigate)\n",
 			result.Page, result.TotalPages)
 	}
 
 	return nil
 }
 
-// displayGetTable displays a single record in table format
-func displayGetTable(record map[string]interface{}, collection, recordID string) error {
+// displaySummaryStats prints min/max/avg/sum for the numeric columns of the
+// listed rows. The stats cover only the fetched page, not the whole collection.
+func displaySummaryStats(w io.Writer, items []map[string]interface{}) {
+	stats := utils.NumericColumnStats(items)
+	if len(stats) == 0 {
+		fmt.Fprintf(w, "\nSummary: no numeric columns\n")
+		return
+	}
+
+	fmt.Fprintf(w, "\nSummary (%d row(s)):\n", len(items))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"FIELD", "MIN", "MAX", "AVG", "SUM"})
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetRowSeparator("")
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("  ")
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetColumnAlignment([]int{tablewriter.ALIGN_LEFT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT, tablewriter.ALIGN_RIGHT})
+	for _, column := range stats {
+		table.Append([]string{
+			column.Field,
+			formatStat(column.Min),
+			formatStat(column.Max),
+			formatStat(column.Avg()),
+			formatStat(column.Sum),
+		})
+	}
+	table.Render()
+}
+
+// formatStat renders a summary value, rounded to two decimal places.
+func formatStat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// formatCount renders a count for display, with thousands separators under --humanize
+func formatCount(n int) string {
+	if humanizeFlag {
+		return utils.FormatNumber(n)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// truncatedIDPrefix and truncatedIDSuffix are the characters of a record ID kept
+// by --truncate-ids; enough to tell records apart at a glance.
+const (
+	truncatedIDPrefix = 4
+	truncatedIDSuffix = 3
+)
+
+// truncateID shortens an ID to its first and last few characters, e.g.
+// "abcdefghijklmno" -> "abcd…mno". IDs too short to gain anything are kept.
+func truncateID(id string) string {
+	runes := []rune(id)
+	if len(runes) <= truncatedIDPrefix+truncatedIDSuffix+1 {
+		return id
+	}
+	return string(runes[:truncatedIDPrefix]) + "…" + string(runes[len(runes)-truncatedIDSuffix:])
+}
+
+// withTruncatedIDs returns copies of items with their "id" shortened for display,
+// leaving the fetched records untouched.
+func withTruncatedIDs(items []map[string]interface{}) []map[string]interface{} {
+	truncated := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		copied := make(map[string]interface{}, len(item))
+		for key, value := range item {
+			copied[key] = value
+		}
+		if id, ok := item["id"].(string); ok {
+			copied["id"] = truncateID(id)
+		}
+		truncated[i] = copied
+	}
+	return truncated
+}
+
+// displayGetTable writes a single record in table format to w
+func displayGetTable(w io.Writer, record map[string]interface{}, collection, recordID string) error {
 	if record == nil {
 		return fmt.Errorf("no record data received")
 	}
 
 	// Show header
-	fmt.Printf("%s Record: %s\n", utils.TitleCase(collection), recordID)
-	fmt.Println(strings.Repeat("=", 50))
+	fmt.Fprintf(w, "%s Record: %s\n", utils.TitleCase(collection), recordID)
+	fmt.Fprintln(w, strings.Repeat("=", 50))
 
 	// Display record details in an organized way
-	if err := displayRecordDetails(record, collection); err != nil {
+	if err := displayRecordDetails(w, record, collection); err != nil {
 		// Fallback to generic table if specific display fails
-		return utils.OutputData(record, config.OutputFormatTable)
+		return utils.OutputDataTo(w, record, config.OutputFormatTable)
 	}
 
 	return nil
 }
 
 // displayRecordDetails displays record details with intelligent field ordering
-func displayRecordDetails(record map[string]interface{}, collection string) error {
+func displayRecordDetails(w io.Writer, record map[string]interface{}, collection string) error {
 	// Common important fields that should be displayed first
 	priorityFields := []string{"id", "name", "title", "email", "username"}
 
@@ -80,7 +172,7 @@ func displayRecordDetails(record map[string]interface{}, collection string) erro
 	// Display priority fields first
 	for _, field := range priorityFields {
 		if value, exists := record[field]; exists && value != nil {
-			fmt.Printf("  %s: %v\n", utils.TitleCase(field), value)
+			fmt.Fprintf(w, "  %s: %v\n", utils.TitleCase(field), value)
 		}
 	}
 
@@ -88,14 +180,14 @@ func displayRecordDetails(record map[string]interface{}, collection string) erro
 	for _, field := range descriptiveFields {
 		if value, exists := record[field]; exists && value != nil {
 			displayValue := formatFieldValue(value)
-			fmt.Printf("  %s: %s\n", utils.TitleCase(field), displayValue)
+			fmt.Fprintf(w, "  %s: %s\n", utils.TitleCase(field), displayValue)
 		}
 	}
 
 	// Display status fields
 	for _, field := range statusFields {
 		if value, exists := record[field]; exists && value != nil {
-			fmt.Printf("  %s: %v\n", utils.TitleCase(field), value)
+			fmt.Fprintf(w, "  %s: %v\n", utils.TitleCase(field), value)
 		}
 	}
 
@@ -112,22 +204,24 @@ func displayRecordDetails(record map[string]interface{}, collection string) erro
 	for key, value := range record {
 		if !skipFields[key] && value != nil {
 			displayValue := formatFieldValue(value)
-			fmt.Printf("  %s: %s\n", utils.TitleCase(key), displayValue)
+			fmt.Fprintf(w, "  %s: %s\n", utils.TitleCase(key), displayValue)
 		}
 	}
 
 	// Display time fields last
 	for _, field := range timeFields {
-		if value, exists := record[field]; exists && value != nil {
-			fmt.Printf("  %s: %v\n", utils.TitleCase(field), value)
+		if t, ok := pocketbase.Record(record).GetTime(field); ok {
+			fmt.Fprintf(w, "  %s: %s\n", utils.TitleCase(field), t.Format("2006-01-02 15:04:05"))
+		} else if value, exists := record[field]; exists && value != nil && value != "" {
+			fmt.Fprintf(w, "  %s: %v\n", utils.TitleCase(field), value)
 		}
 	}
 
 	// Display expanded relations
 	if expand, exists := record["expand"]; exists && expand != nil {
-		fmt.Printf("\nExpanded Relations:\n")
-		if err := displayExpandedRelations(expand); err != nil {
-			fmt.Printf("  %v\n", expand)
+		fmt.Fprintf(w, "\nExpanded Relations:\n")
+		if err := displayExpandedRelations(w, expand, 0); err != nil {
+			fmt.Fprintf(w, "  %v\n", expand)
 		}
 	}
 
@@ -163,65 +257,54 @@ func formatFieldValue(value interface{}) string {
 	}
 }
 
-// displayExpandedRelations displays expanded relation data
-func displayExpandedRelations(expand interface{}) error {
-	switch expandData := expand.(type) {
-	case map[string]interface{}:
-		for relationName, relationData := range expandData {
-			fmt.Printf("  %s:\n", utils.TitleCase(relationName))
-
-			switch relData := relationData.(type) {
-			case []interface{}:
-				// Multiple related records
-				for i, item := range relData {
-					if itemMap, ok := item.(map[string]interface{}); ok {
-						if name := getRecordDisplayName(itemMap); name != "" {
-							fmt.Printf("    %d. %s\n", i+1, name)
-						} else {
-							fmt.Printf("    %d. %v\n", i+1, item)
-						}
+// displayExpandedRelations displays expanded relation data, recursing into nested
+// expands (e.g. author.profile) with deeper indentation at each level
+func displayExpandedRelations(w io.Writer, expand interface{}, depth int) error {
+	expandData, ok := expand.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unexpected expand data format")
+	}
+
+	indent := strings.Repeat("    ", depth)
+	for relationName, relationData := range expandData {
+		fmt.Fprintf(w, "%s  %s:\n", indent, utils.TitleCase(relationName))
+
+		switch relData := relationData.(type) {
+		case []interface{}:
+			// Multiple related records
+			for i, item := range relData {
+				if itemMap, ok := item.(map[string]interface{}); ok {
+					if name := pocketbase.Record(itemMap).GetDisplayName(); name != "" {
+						fmt.Fprintf(w, "%s    %d. %s\n", indent, i+1, name)
+					} else {
+						fmt.Fprintf(w, "%s    %d. %v\n", indent, i+1, item)
 					}
+					displayNestedExpand(w, itemMap, depth)
 				}
-			case map[string]interface{}:
-				// Single related record
-				if name := getRecordDisplayName(relData); name != "" {
-					fmt.Printf("    %s\n", name)
-				} else {
-					fmt.Printf("    %v\n", relData)
-				}
-			default:
-				fmt.Printf("    %v\n", relData)
 			}
+		case map[string]interface{}:
+			// Single related record
+			if name := pocketbase.Record(relData).GetDisplayName(); name != "" {
+				fmt.Fprintf(w, "%s    %s\n", indent, name)
+			} else {
+				fmt.Fprintf(w, "%s    %v\n", indent, relData)
+			}
+			displayNestedExpand(w, relData, depth)
+		default:
+			fmt.Fprintf(w, "%s    %v\n", indent, relData)
 		}
-	default:
-		return fmt.Errorf("unexpected expand data format")
 	}
 
 	return nil
 }
 
-// getRecordDisplayName attempts to get a display name for a record
-func getRecordDisplayName(record map[string]interface{}) string {
-	// Try common name fields
-	nameFields := []string{"name", "title", "display_name", "full_name"}
-	for _, field := range nameFields {
-		if name, ok := record[field].(string); ok && name != "" {
-			return name
-		}
+// displayNestedExpand renders a related record's own expand one level deeper
+func displayNestedExpand(w io.Writer, record map[string]interface{}, depth int) {
+	nested, exists := record["expand"]
+	if !exists || nested == nil {
+		return
 	}
-
-	// Try email or username
-	if email, ok := record["email"].(string); ok && email != "" {
-		return email
+	if err := displayExpandedRelations(w, nested, depth+1); err != nil {
+		fmt.Fprintf(w, "%s      %v\n", strings.Repeat("    ", depth), nested)
 	}
-	if username, ok := record["username"].(string); ok && username != "" {
-		return username
-	}
-
-	// Fallback to ID
-	if id, ok := record["id"].(string); ok {
-		return fmt.Sprintf("ID: %s", id)
-	}
-
-	return ""
 }
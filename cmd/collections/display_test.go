@@ -0,0 +1,70 @@
+package collections
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDisplayExpandedRelationsNested checks that a two-level expand (author.profile)
+// renders the nested record's name, indented under its parent.
+func TestDisplayExpandedRelationsNested(t *testing.T) {
+	expand := map[string]interface{}{
+		"author": map[string]interface{}{
+			"id":   "user_1",
+			"name": "Ada",
+			"expand": map[string]interface{}{
+				"profile": map[string]interface{}{
+					"id":    "profile_1",
+					"title": "Ada's profile",
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, displayExpandedRelations(&buf, expand, 0))
+
+	assert.Equal(t, "  Author:\n    Ada\n      Profile:\n        Ada's profile\n", buf.String())
+}
+
+// TestDisplayExpandedRelationsNestedList checks nested expands inside multi-relations.
+func TestDisplayExpandedRelationsNestedList(t *testing.T) {
+	expand := map[string]interface{}{
+		"tags": []interface{}{
+			map[string]interface{}{
+				"id":   "tag_1",
+				"name": "go",
+				"expand": map[string]interface{}{
+					"owner": map[string]interface{}{"id": "user_2", "email": "bob@example.com"},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, displayExpandedRelations(&buf, expand, 0))
+
+	assert.Equal(t, "  Tags:\n    1. go\n      Owner:\n        bob@example.com\n", buf.String())
+}
+
+// TestDisplayExpandedRelationsInvalid rejects non-object expand data.
+func TestDisplayExpandedRelationsInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, displayExpandedRelations(&buf, "not-a-map", 0))
+}
+
+// TestTruncateID checks --truncate-ids shortening and that source records are untouched.
+func TestTruncateID(t *testing.T) {
+	assert.Equal(t, "abcd…mno", truncateID("abcdefghijklmno"))
+	assert.Equal(t, "short", truncateID("short"))
+	assert.Equal(t, "abcdefgh", truncateID("abcdefgh"))
+
+	items := []map[string]interface{}{{"id": "abcdefghijklmno", "title": "A"}}
+	truncated := withTruncatedIDs(items)
+	assert.Equal(t, "abcd…mno", truncated[0]["id"])
+	assert.Equal(t, "A", truncated[0]["title"])
+	assert.Equal(t, "abcdefghijklmno", items[0]["id"])
+}
@@ -0,0 +1,240 @@
+package collections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var editQuietFlag bool
+
+// readOnlyRecordFields are managed by PocketBase and never sent back on edit.
+var readOnlyRecordFields = []string{"id", "created", "updated", "collectionId", "collectionName"}
+
+var editCmd = &cobra.Command{
+	Use:   "edit <collection> <id>",
+	Short: "Edit a record in your editor",
+	Long: `Open a record as JSON in your editor and update it with the changes you save.
+
+The editor is taken from $VISUAL, then $EDITOR, and defaults to vi. Editors that
+return immediately need their wait flag, e.g. EDITOR="code --wait".
+
+Only fields whose values changed are sent. Removing a field from the JSON clears
+it (sends null). Changes to id, created, updated, collectionId, and
+collectionName are ignored. If nothing changed, no update is made. If the saved
+file is not valid JSON, nothing is sent and the file is kept so your edits aren't
+lost.
+
+Examples:
+  pb collections edit posts post_123
+  EDITOR=nano pb collections edit users user_456
+  pb c edit posts post_123 -o json --quiet`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection := args[0]
+		recordID := args[1]
+
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		if err := validateRecordID(recordID); err != nil {
+			return fmt.Errorf("invalid record ID: %w", err)
+		}
+
+		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		original, err := client.GetRecord(collection, recordID, nil, nil)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("failed to fetch record")
+			}
+			return fmt.Errorf("failed to fetch record: %w", err)
+		}
+		delete(original, "expand")
+
+		content, err := json.MarshalIndent(original, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
+		}
+		content = append(content, '\n')
+
+		edited, path, err := editInEditor(content, "pb-edit-"+collection+"-*.json")
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(edited, content) {
+			os.Remove(path)
+			fmt.Fprintln(os.Stderr, "No changes made.")
+			return nil
+		}
+
+		var before, after map[string]interface{}
+		if err := json.Unmarshal(content, &before); err != nil {
+			os.Remove(path)
+			return fmt.Errorf("failed to decode record: %w", err)
+		}
+		if err := json.Unmarshal(edited, &after); err != nil {
+			return fmt.Errorf("edited record is not a valid JSON object (your changes are saved in %s): %w", path, err)
+		}
+		os.Remove(path)
+
+		changes, ignored := recordChanges(before, after)
+		if len(ignored) > 0 {
+			utils.PrintWarning(fmt.Sprintf("ignoring changes to read-only fields: %s", strings.Join(ignored, ", ")))
+		}
+		if len(changes) == 0 {
+			fmt.Fprintln(os.Stderr, "No changes made.")
+			return nil
+		}
+
+		if err := validateUpdateData(changes, collection); err != nil {
+			return fmt.Errorf("invalid update data: %w", err)
+		}
+		if err := checkPayloadSize(changes, false); err != nil {
+			return err
+		}
+
+		utils.PrintDebug(fmt.Sprintf("Updating record '%s' in collection '%s' with changes: %+v", recordID, collection, changes))
+
+		record, err := client.UpdateRecord(collection, recordID, changes, nil)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("failed to update record")
+			}
+			return fmt.Errorf("failed to update record: %w", err)
+		}
+
+		if !editQuietFlag {
+			green := color.New(color.FgGreen).SprintFunc()
+			fields := make([]string, 0, len(changes))
+			for field := range changes {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+
+			fmt.Fprintf(os.Stderr, "%s Record updated successfully!\n", green("✓"))
+			fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
+			fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
+			fmt.Fprintf(os.Stderr, "  Changed: %s\n", strings.Join(fields, ", "))
+			fmt.Fprintf(os.Stderr, "\nUpdated Record:\n")
+		}
+
+		outputFormat := getOutputFormat()
+		switch outputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatTable, config.OutputFormatID:
+			return utils.OutputData(record, outputFormat)
+		default:
+			return fmt.Errorf("unsupported output format: %s", outputFormat)
+		}
+	},
+}
+
+func init() {
+	editCmd.Flags().BoolVarP(&editQuietFlag, "quiet", "q", false, "Suppress the success summary; print only the record")
+}
+
+// editorCommand returns the user's editor command line: $VISUAL, then $EDITOR,
+// then vi. The value is split on spaces so flags like "code --wait" work.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// editInEditor writes content to a temp file named after pattern, opens it in
+// the user's editor, and returns the saved content and the file's path. The
+// caller removes the file; it is removed here only on error.
+func editInEditor(content []byte, pattern string) ([]byte, string, error) {
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := file.Name()
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return nil, "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	editor := editorCommand()
+	run := exec.Command(editor[0], append(editor[1:], path)...)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := run.Run(); err != nil {
+		os.Remove(path)
+		return nil, "", fmt.Errorf("editor '%s' failed: %w", strings.Join(editor, " "), err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		os.Remove(path)
+		return nil, "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return edited, path, nil
+}
+
+// recordChanges returns the fields of after that differ from before, with fields
+// removed from after set to nil. Read-only fields are left out; the ones that
+// were changed are returned, sorted, as ignored.
+func recordChanges(before, after map[string]interface{}) (changes map[string]interface{}, ignored []string) {
+	readOnly := make(map[string]bool, len(readOnlyRecordFields))
+	for _, field := range readOnlyRecordFields {
+		readOnly[field] = true
+	}
+
+	changes = make(map[string]interface{})
+	for field, value := range after {
+		old, existed := before[field]
+		if existed && reflect.DeepEqual(old, value) {
+			continue
+		}
+		if readOnly[field] {
+			ignored = append(ignored, field)
+			continue
+		}
+		changes[field] = value
+	}
+	for field := range before {
+		if _, kept := after[field]; kept {
+			continue
+		}
+		if readOnly[field] {
+			ignored = append(ignored, field)
+			continue
+		}
+		changes[field] = nil
+	}
+
+	sort.Strings(ignored)
+	return changes, ignored
+}
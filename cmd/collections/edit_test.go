@@ -0,0 +1,45 @@
+package collections
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordChanges checks that only changed fields are sent, removed fields are
+// cleared, and read-only fields are never sent.
+func TestRecordChanges(t *testing.T) {
+	before := map[string]interface{}{
+		"id": "abc", "updated": "2024-01-01", "title": "Old", "views": float64(3),
+		"tags": []interface{}{"a"}, "subtitle": "gone",
+	}
+	after := map[string]interface{}{
+		"id": "xyz", "title": "New", "views": float64(3),
+		"tags": []interface{}{"a"}, "published": true,
+	}
+
+	changes, ignored := recordChanges(before, after)
+	assert.Equal(t, map[string]interface{}{"title": "New", "published": true, "subtitle": nil}, changes)
+	assert.Equal(t, []string{"id", "updated"}, ignored)
+
+	changes, ignored = recordChanges(before, before)
+	assert.Empty(t, changes)
+	assert.Empty(t, ignored)
+}
+
+// TestEditInEditor runs a non-interactive "editor" over the temp file.
+func TestEditInEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "sed -i s/old/new/")
+
+	edited, path, err := editInEditor([]byte(`{"title":"old"}`), "pb-edit-test-*.json")
+	require.NoError(t, err)
+	defer os.Remove(path)
+	assert.Equal(t, `{"title":"new"}`, string(edited))
+
+	t.Setenv("EDITOR", "false")
+	_, _, err = editInEditor([]byte(`{}`), "pb-edit-test-*.json")
+	assert.ErrorContains(t, err, "editor 'false' failed")
+}
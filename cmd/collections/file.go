@@ -0,0 +1,222 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	fileThumbFlag string
+	fileNameFlag  string
+	fileForceFlag bool
+	fileQuietFlag bool
+)
+
+var fileCmd = &cobra.Command{
+	Use:   "file <collection> <id> <field> [output]",
+	Short: "Download a file stored in a record's file field",
+	Long: `Download a file stored in a record's file field.
+
+The stored filename is read from the record. When you are authenticated and the
+field is protected (or the schema can't be read to tell), a file token is
+requested first so the file downloads; if the server refuses the token, the
+download is tried without one.
+
+output may be a directory, in which case the file keeps its stored name, or a
+file path. Without output the file is saved in the current directory. A field
+holding several files downloads all of them into the directory; use --name to
+pick one. Existing files are not overwritten without --force.
+
+Examples:
+  pb collections file users user_123 avatar
+  pb collections file users user_123 avatar ./me.jpg
+  pb collections file posts post_123 attachments ./downloads/
+  pb collections file posts post_123 attachments --name spec_a1b2c3.pdf
+  pb collections file posts post_123 cover --thumb 100x100 cover-small.png`,
+	Args: cobra.RangeArgs(3, 4),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection := args[0]
+		recordID := args[1]
+		field := args[2]
+		var output string
+		if len(args) > 3 {
+			output = args[3]
+		}
+
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		if err := validateRecordID(recordID); err != nil {
+			return fmt.Errorf("invalid record ID: %w", err)
+		}
+
+		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		record, err := client.GetRecord(collection, recordID, nil, nil)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("failed to get record")
+			}
+			return fmt.Errorf("failed to get record: %w", err)
+		}
+
+		names, err := recordFileNames(record, field)
+		if err != nil {
+			return err
+		}
+		if fileNameFlag != "" {
+			if !slices.Contains(names, fileNameFlag) {
+				return fmt.Errorf("field '%s' has no file named '%s' (files: %s)", field, fileNameFlag, strings.Join(names, ", "))
+			}
+			names = []string{fileNameFlag}
+		}
+
+		targets, err := fileTargets(output, names)
+		if err != nil {
+			return fmt.Errorf("field '%s' holds %d files: %w", field, len(names), err)
+		}
+		for _, target := range targets {
+			if _, err := os.Stat(target); err == nil && !fileForceFlag {
+				return fmt.Errorf("output file already exists: %s (use --force to overwrite)", target)
+			}
+		}
+
+		// PocketBase accepts the collection name here, but the ID survives renames.
+		collectionID := pocketbase.Record(record).GetString("collectionId")
+		if collectionID == "" {
+			collectionID = collection
+		}
+
+		protected := fileFieldProtected(client, collection, field)
+
+		green := color.New(color.FgGreen).SprintFunc()
+		for i, name := range names {
+			var progress *utils.ProgressReporter
+			var callback func(downloaded, total int64)
+			if !fileQuietFlag {
+				progress = utils.NewProgressReporter(os.Stderr, utils.DefaultProgressInterval)
+				callback = progress.Update
+			}
+			err := client.DownloadRecordFile(collectionID, recordID, name, fileThumbFlag, targets[i], protected, callback)
+			if progress != nil {
+				progress.Finish()
+			}
+			if err != nil {
+				if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+					utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+					return fmt.Errorf("failed to download file")
+				}
+				return fmt.Errorf("failed to download file: %w", err)
+			}
+			if !fileQuietFlag {
+				fmt.Fprintf(os.Stderr, "%s Saved %s to %s\n", green("✓"), name, targets[i])
+			}
+		}
+		return nil
+	},
+}
+
+// recordFileNames returns the stored filenames of a single or multiple file field.
+func recordFileNames(record map[string]interface{}, field string) ([]string, error) {
+	value, exists := record[field]
+	if !exists {
+		return nil, fmt.Errorf("record has no field '%s'", field)
+	}
+
+	var names []string
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			names = append(names, v)
+		}
+	case []interface{}:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("field '%s' is not a file field", field)
+			}
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("field '%s' is not a file field", field)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("field '%s' has no file", field)
+	}
+	return names, nil
+}
+
+// fileFieldProtected reports whether field is a protected file field, whose files
+// need a file token. Only superusers can read the schema; when it is unavailable
+// the field is treated as protected, since DownloadRecordFile falls back to a
+// plain download if the token is refused.
+func fileFieldProtected(client *pocketbase.Client, collection, field string) bool {
+	if !client.IsAuthenticated() {
+		return false
+	}
+	schema, err := client.GetCollectionSchema(collection)
+	if err != nil {
+		utils.PrintDebug(fmt.Sprintf("Could not read schema for '%s' (%v); requesting a file token", collection, err))
+		return true
+	}
+	for _, f := range schema.Fields {
+		if f.Name == field {
+			return f.Protected
+		}
+	}
+	return true
+}
+
+// fileTargets maps each stored filename to a local path. output is treated as
+// a directory when it is empty, an existing directory, or ends in a separator;
+// otherwise it names the file and only one file may be downloaded.
+func fileTargets(output string, names []string) ([]string, error) {
+	dir := output
+	if dir == "" {
+		dir = "."
+	}
+	isDir := output == "" || strings.HasSuffix(output, string(os.PathSeparator)) || strings.HasSuffix(output, "/")
+	if info, err := os.Stat(output); err == nil && info.IsDir() {
+		isDir = true
+	}
+
+	if !isDir {
+		if len(names) > 1 {
+			return nil, fmt.Errorf("give a directory to save them all, or pick one with --name")
+		}
+		return []string{output}, nil
+	}
+
+	targets := make([]string, len(names))
+	for i, name := range names {
+		targets[i] = filepath.Join(dir, filepath.Base(name))
+	}
+	return targets, nil
+}
+
+func init() {
+	fileCmd.Flags().StringVar(&fileThumbFlag, "thumb", "", "Download an image thumbnail of this size instead (e.g. 100x100, 0x300, 100x100f)")
+	fileCmd.Flags().StringVar(&fileNameFlag, "name", "", "Stored filename to download when the field holds several files")
+	fileCmd.Flags().BoolVarP(&fileForceFlag, "force", "f", false, "Overwrite existing output files")
+	fileCmd.Flags().BoolVarP(&fileQuietFlag, "quiet", "q", false, "Suppress progress and the saved-file message")
+}
@@ -0,0 +1,186 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/utils"
+)
+
+var presetFilterFlag string
+
+var filterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Manage named filter presets",
+	Long: `Save and reuse named filter expressions per collection.
+
+Presets are stored in the active context's directory (filters.yaml), so each
+environment keeps its own. Use a preset with 'pb collections list <collection>
+--filter-preset <name>'.
+
+Examples:
+  pb collections filter save posts recent --filter 'created>="2024-01-01"'
+  pb collections filter list posts
+  pb collections list posts --filter-preset recent
+  pb collections filter delete posts recent`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("missing subcommand. Available: save, list, delete")
+	},
+}
+
+var filterSaveCmd = &cobra.Command{
+	Use:   "save <collection> <name> --filter <expression>",
+	Short: "Save a filter preset for a collection",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection, name := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+		if collection == "" || name == "" {
+			return fmt.Errorf("collection and preset name cannot be empty")
+		}
+		if strings.TrimSpace(presetFilterFlag) == "" {
+			return fmt.Errorf("--filter cannot be empty")
+		}
+
+		ctx, presets, err := loadActiveFilterPresets()
+		if err != nil {
+			return err
+		}
+
+		_, replaced := presets.Get(collection, name)
+		presets.Set(collection, name, presetFilterFlag)
+		if err := configManager.SaveFilterPresets(ctx.Name, presets); err != nil {
+			return err
+		}
+
+		action := "Saved"
+		if replaced {
+			action = "Updated"
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Fprintf(os.Stderr, "%s %s filter preset '%s' for '%s' in context '%s'\n", green("✓"), action, name, collection, ctx.Name)
+		return nil
+	},
+}
+
+var filterListCmd = &cobra.Command{
+	Use:   "list [collection]",
+	Short: "List saved filter presets",
+	Long: `List the filter presets saved in the active context, for one collection or all.
+
+Examples:
+  pb collections filter list
+  pb collections filter list posts -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, presets, err := loadActiveFilterPresets()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			selected := config.FilterPresets{}
+			if named, exists := presets[args[0]]; exists {
+				selected[args[0]] = named
+			}
+			presets = selected
+		}
+
+		outputFormat := getOutputFormat()
+		switch outputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML:
+			return utils.OutputData(presets, outputFormat)
+		case config.OutputFormatTable:
+			var rows []map[string]interface{}
+			for collection, named := range presets {
+				for name, filter := range named {
+					rows = append(rows, map[string]interface{}{"collection": collection, "name": name, "filter": filter})
+				}
+			}
+			if len(rows) == 0 {
+				fmt.Fprintln(utils.DataOutput(), "No filter presets saved.")
+				return nil
+			}
+			sort.Slice(rows, func(i, j int) bool {
+				if rows[i]["collection"] != rows[j]["collection"] {
+					return rows[i]["collection"].(string) < rows[j]["collection"].(string)
+				}
+				return rows[i]["name"].(string) < rows[j]["name"].(string)
+			})
+			return utils.OutputData(rows, config.OutputFormatTable)
+		default:
+			return fmt.Errorf("unsupported output format: %s", outputFormat)
+		}
+	},
+}
+
+var filterDeleteCmd = &cobra.Command{
+	Use:   "delete <collection> <name>",
+	Short: "Delete a filter preset",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection, name := args[0], args[1]
+
+		ctx, presets, err := loadActiveFilterPresets()
+		if err != nil {
+			return err
+		}
+
+		if !presets.Delete(collection, name) {
+			return fmt.Errorf("no filter preset '%s' for '%s' in context '%s'", name, collection, ctx.Name)
+		}
+		if err := configManager.SaveFilterPresets(ctx.Name, presets); err != nil {
+			return err
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Fprintf(os.Stderr, "%s Deleted filter preset '%s' for '%s' in context '%s'\n", green("✓"), name, collection, ctx.Name)
+		return nil
+	},
+}
+
+func init() {
+	filterSaveCmd.Flags().StringVar(&presetFilterFlag, "filter", "", "Filter expression to save (required)")
+	filterSaveCmd.MarkFlagRequired("filter")
+
+	filterCmd.AddCommand(filterSaveCmd)
+	filterCmd.AddCommand(filterListCmd)
+	filterCmd.AddCommand(filterDeleteCmd)
+}
+
+// loadActiveFilterPresets returns the active context and its filter presets.
+// Presets are local configuration, so no authentication is needed.
+func loadActiveFilterPresets() (*config.Context, config.FilterPresets, error) {
+	if err := validateConfigManager(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, err := configManager.GetActiveContext()
+	if err != nil {
+		return nil, nil, fmt.Errorf("no active context set. Use 'pb context select <name>' to set one")
+	}
+
+	presets, err := configManager.LoadFilterPresets(ctx.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ctx, presets, nil
+}
+
+// resolveFilterPreset returns the filter saved as name for collection in ctx.
+func resolveFilterPreset(ctx *config.Context, collection, name string) (string, error) {
+	presets, err := configManager.LoadFilterPresets(ctx.Name)
+	if err != nil {
+		return "", err
+	}
+
+	filter, exists := presets.Get(collection, name)
+	if !exists {
+		return "", fmt.Errorf("no filter preset '%s' for '%s'. Use 'pb collections filter list %s' to see saved presets", name, collection, collection)
+	}
+	return filter, nil
+}
@@ -1,6 +1,7 @@
 package collections
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -11,8 +12,11 @@ import (
 )
 
 var (
-	getFieldsFlag []string
-	getExpandFlag []string
+	getFieldsFlag   []string
+	getExpandFlag   []string
+	getRawValueFlag string
+	getQueryFlag    string
+	getMetaFlag     bool
 )
 
 var getCmd = &cobra.Command{
@@ -20,16 +24,45 @@ var getCmd = &cobra.Command{
 	Short: "Get a single record by ID",
 	Long: `Get a single record from a collection by its ID.
 
+Use --raw-value to print just one field's value with no wrapping: strings are
+written verbatim and other types (numbers, arrays, objects) as compact JSON.
+Use --query to reach into nested values with a dotted path (expand.author.name,
+tags.0); strings print unquoted on their own line, handy for shell scripts.
+
+--include-collection-meta adds a "_schema" block to json, yaml, and ndjson
+output with the collection's name and type and the type and required flag of
+each field in the record, for tools that render records without knowing the
+schema. It costs one more request and needs superuser auth.
+
 Examples:
   pb collections get posts post_123
   pb collections get users user_abc --expand profile
   pb collections get posts post_123 --fields title,content --output yaml
+  pb collections get posts post_123 --raw-value content > body.md
+  pb collections get users $ID --query email
+  pb collections get posts post_123 --expand author --query expand.author.name
+  pb collections get posts post_123 --include-collection-meta
   pb c get posts post_123`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		collection := args[0]
 		recordID := args[1]
 
+		if getQueryFlag != "" && cmd.Flags().Changed("output") {
+			return fmt.Errorf("--query prints a single value; it cannot be used with --output")
+		}
+		outputFormat := getOutputFormat()
+		if getMetaFlag {
+			if getRawValueFlag != "" || getQueryFlag != "" {
+				return fmt.Errorf("--include-collection-meta cannot be used with --raw-value or --query")
+			}
+			switch outputFormat {
+			case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatNDJSON:
+			default:
+				return fmt.Errorf("--include-collection-meta applies to json, yaml, and ndjson output, not %s", outputFormat)
+			}
+		}
+
 		ctx, err := validateActiveContext()
 		if err != nil {
 			return err
@@ -40,11 +73,14 @@ Examples:
 		}
 
 		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
 
 		utils.PrintDebug(fmt.Sprintf("Getting record '%s' from collection '%s' with expand=%v, fields=%v",
 			recordID, collection, getExpandFlag, getFieldsFlag))
 
-		record, err := client.GetRecord(collection, recordID, getExpandFlag, getFieldsFlag)
+		record, err := client.GetRecord(collection, recordID, getExpandFlag, withExpandFields(getFieldsFlag, getExpandFlag))
 		if err != nil {
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
@@ -56,7 +92,31 @@ Examples:
 			return fmt.Errorf("failed to get record: %w", err)
 		}
 
-		outputFormat := getOutputFormat()
+		if getRawValueFlag != "" {
+			return printRawValue(record, getRawValueFlag)
+		}
+		if getQueryFlag != "" {
+			value, err := utils.QueryPath(record, getQueryFlag)
+			if err != nil {
+				return fmt.Errorf("invalid --query: %w", err)
+			}
+			return utils.WriteQueryValue(utils.DataOutput(), value)
+		}
+
+		if getMetaFlag {
+			schema, err := client.GetCollectionSchema(collection)
+			if err != nil {
+				if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+					utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+					if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+						fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+					}
+					return fmt.Errorf("failed to get collection schema")
+				}
+				return fmt.Errorf("failed to get collection schema: %w", err)
+			}
+			record["_schema"] = recordSchemaMeta(schema, record)
+		}
 
 		switch outputFormat {
 		case config.OutputFormatJSON:
@@ -64,7 +124,13 @@ Examples:
 		case config.OutputFormatYAML:
 			return utils.OutputData(record, config.OutputFormatYAML)
 		case config.OutputFormatTable:
-			return displayGetTable(record, collection, recordID)
+			return displayGetTable(utils.DataOutput(), record, collection, recordID)
+		case config.OutputFormatID:
+			return utils.OutputData(record, config.OutputFormatID)
+		case config.OutputFormatCSV:
+			return utils.OutputData(record, config.OutputFormatCSV)
+		case config.OutputFormatNDJSON:
+			return utils.OutputData(record, config.OutputFormatNDJSON)
 		default:
 			return fmt.Errorf("unsupported output format: %s", outputFormat)
 		}
@@ -74,4 +140,51 @@ Examples:
 func init() {
 	getCmd.Flags().StringSliceVar(&getFieldsFlag, "fields", nil, "Specific fields to return (comma-separated)")
 	getCmd.Flags().StringSliceVar(&getExpandFlag, "expand", nil, "Relations to expand (comma-separated)")
+	getCmd.Flags().StringVar(&getRawValueFlag, "raw-value", "", "Print only this field's value (strings verbatim, other types as JSON)")
+	getCmd.Flags().StringVar(&getQueryFlag, "query", "", "Print only the value at this dotted path (e.g. expand.author.name)")
+	getCmd.Flags().BoolVar(&getMetaFlag, "include-collection-meta", false, "Add a _schema block with the collection type and field types (one more request)")
+	getCmd.MarkFlagsMutuallyExclusive("raw-value", "query")
+}
+
+// recordSchemaMeta describes schema for the _schema block of get
+// --include-collection-meta: the collection's name and type and, for each field
+// present in record, its type and whether it is required.
+func recordSchemaMeta(schema *pocketbase.Collection, record map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for _, field := range schema.Fields {
+		if _, ok := record[field.Name]; !ok {
+			continue
+		}
+		fields[field.Name] = map[string]interface{}{
+			"type":     field.Type,
+			"required": field.Required,
+		}
+	}
+	return map[string]interface{}{
+		"collection": schema.Name,
+		"type":       schema.Type,
+		"fields":     fields,
+	}
+}
+
+// printRawValue writes a single field of record to stdout without any wrapping.
+func printRawValue(record map[string]interface{}, field string) error {
+	value, exists := record[field]
+	if !exists {
+		return fmt.Errorf("field '%s' not found in record", field)
+	}
+
+	if str, ok := value.(string); ok {
+		// Written exactly as stored, without an added newline, so redirecting to a
+		// file reproduces the value byte for byte.
+		_, err := fmt.Fprint(utils.DataOutput(), str)
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode field '%s': %w", field, err)
+	}
+	_, err = fmt.Fprintln(utils.DataOutput(), string(data))
+	return err
 }
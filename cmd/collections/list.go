@@ -1,8 +1,14 @@
 package collections
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"pb-cli/internal/config"
@@ -11,33 +17,143 @@ import (
 )
 
 var (
-	pageFlag   int
-	limitFlag  int
-	allFlag    bool
-	filterFlag string
-	sortFlag   string
-	fieldsFlag []string
-	expandFlag []string
+	pageFlag         int
+	limitFlag        int
+	allFlag          bool
+	filterFlag       string
+	filterPresetFlag string
+	filterValueFlags []string
+	whereFlags       []string
+	sortFlag         string
+	fieldsFlag       []string
+	projectionFlag   string
+	seekFlag         string
+	expandFlag       []string
+	collectionsFlag  []string
+
+	humanizeFlag       bool
+	summaryStatsFlag   bool
+	truncateIDsFlag    bool
+	maxColLinesFlag    int
+	listQueryFlag      string
+	withMetaFlag       bool
+	unwrapFlag         bool
+	idsFileFlag        string
+	streamFlag         bool
+	noHeaderFlag       bool
+	delimiterFlag      string
+	sortDisplayFlag    string
+	updatedSinceFlag   string
+	followFlag         bool
+	followIntervalFlag time.Duration
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list <collection>",
+	Use:   "list [collection]",
 	Short: "List records from a collection",
 	Long: `List records from a collection with filtering, sorting, and pagination.
 
 By default a single page is returned (--page / --limit). Use --all to fetch every
 matching record across all pages; --all cannot be combined with --page or --limit.
 
+Besides json, yaml, and table, list supports --output html, which renders the
+records as a standalone styled HTML table, and --output csv. CSV output takes
+--delimiter (e.g. '\t' for TSV or ';') and --no-header, which omits the header row
+so paginated exports can be appended to one file. Use --output-file to write the
+result to a file instead of stdout.
+
+--projection-preset picks the fields to return without spelling them out:
+minimal requests id and the fields marked presentable in the schema, display
+requests the fields used to name records (name, title, email, ...), and full
+requests every field, overriding context default fields. --fields overrides it.
+
+--seek pages by cursor instead of page number: it lists the records whose id
+sorts after the given ID, in id order, so records added or deleted meanwhile
+don't shift the pages. When a list sorted by id (--seek, or --sort id for the
+first page) has more records, the ID to continue from is printed to stderr as
+"Next page: --seek <id>".
+
+--output keys prints only the record IDs, one per line (with --all, for every
+matching record), for piping into other commands. --ids-file writes the same
+list to a file while the records are still printed in the chosen format, to
+select records now and act on them in a later step.
+
+For very large collections, --all --stream -o json writes a JSON array of the
+records page by page as they are fetched, so memory use stays bounded by one page.
+Streamed output is the bare array of records rather than the paginated envelope.
+
+JSON and YAML output default to PocketBase's envelope: {page, perPage,
+totalItems, totalPages, items}. --unwrap prints just the array of records, and
+--with-meta prints {data: [...], meta: {page, perPage, totalItems, totalPages}}.
+
+--sort-display re-sorts the fetched records client-side before rendering, without
+another API call (e.g. to reorder an --all result). Prefix the field with '-' for
+descending order. Unlike --sort, it only orders what was fetched: with pagination
+it sorts the current page, not the whole collection.
+
+--filter-preset applies a filter saved with 'pb collections filter save'.
+
+--filter-value field=value matches a field exactly without hand-quoting: the
+value is single-quoted with any quotes inside escaped, so names like O'Brien
+work. It can be repeated and is combined with --filter or a preset using &&.
+
+--where builds conditions without filter syntax: field=value, field!=value,
+field>value (also >=, <, <=), field~value (contains), or field!~value. Numbers,
+true, false, and null are compared as such; other values are quoted and escaped
+for you (quote a value, as in --where "code='42'", to compare it as a string).
+Repeated --where conditions are ANDed with each other and with --filter.
+
+--updated-since lists only records changed after a point in time, oldest change
+first: a duration back from now (15m, 24h, 7d), a date, or a datetime. Add --follow to
+keep polling (every --follow-interval) and print each new or changed record as a
+line of JSON as it appears, a poll-based change feed for when realtime
+subscriptions are unavailable. Without --updated-since, --follow starts from now.
+
+--query prints just the value at a dotted path into the result, such as
+items.0.id or totalItems; strings print unquoted, other values as compact JSON.
+
+--collections runs the same query against several collections at once instead of
+one named collection. Table output shows a section per collection; json and yaml
+output an object keyed by collection name; csv and html output one combined table
+with a _collection column. Collections that don't exist or can't be read are
+reported and skipped.
+
 Examples:
   pb collections list posts
   pb collections list posts --filter 'published=true' --sort '-created'
   pb collections list users --limit 10 --page 2
   pb collections list posts --all --filter 'published=true'
   pb collections list posts --fields title,content,created --expand author
-  pb c list posts --output table`,
-	Args: cobra.ExactArgs(1),
+  pb collections list users --projection-preset minimal -o table
+  pb collections list posts --sort id --limit 100 -o keys
+  pb collections list posts --seek k2n8x5bq9w3a1zd --limit 100 -o keys
+  pb c list posts --output table
+  pb collections list posts --all -o html --output-file report.html
+  pb collections list posts --page 2 -o csv --no-header >> posts.csv
+  pb collections list posts -o csv --delimiter '\t'
+  pb collections list posts --all --filter 'draft=true' -o keys
+  pb collections list posts --all --filter 'draft=true' -o table --ids-file drafts.txt
+  pb collections list events --all --stream -o json --output-file events.json
+  pb collections list posts --all --sort-display -views
+  pb collections list posts --filter-preset recent
+  pb collections list users --filter-value "name=O'Brien" --filter-value role=admin
+  pb collections list products --where 'price>=10' --where 'stock>0' --where title~lamp
+  pb collections list orders --updated-since 1h --all
+  pb collections list posts --sort -created --limit 1 --query items.0.id
+  pb collections list posts --all --unwrap | jq length
+  pb collections list orders --updated-since 2024-06-01 --follow --filter 'status="paid"'
+  pb collections list --collections posts,comments --filter 'created>"2024-01-01"' --sort -created`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		collection := args[0]
+		if len(args) == 0 && len(collectionsFlag) == 0 {
+			return fmt.Errorf("a collection name or --collections is required")
+		}
+		if len(args) > 0 && len(collectionsFlag) > 0 {
+			return fmt.Errorf("provide either a collection name or --collections, not both")
+		}
+		if getOutputFormat() == config.OutputFormatID {
+			return fmt.Errorf("--output id applies only to a single record (get, create, update); use --output keys to list IDs")
+		}
 
 		ctx, err := validateActiveContext()
 		if err != nil {
@@ -46,6 +162,67 @@ Examples:
 
 		client := createPocketBaseClient(ctx)
 
+		if listQueryFlag != "" {
+			if cmd.Flags().Changed("output") {
+				return fmt.Errorf("--query prints a single value; it cannot be used with --output")
+			}
+			if streamFlag || followFlag || len(collectionsFlag) > 0 {
+				return fmt.Errorf("--query cannot be used with --stream, --follow, or --collections")
+			}
+		}
+
+		if idsFileFlag != "" && (streamFlag || followFlag || len(collectionsFlag) > 0) {
+			return fmt.Errorf("--ids-file cannot be used with --stream, --follow, or --collections")
+		}
+
+		if withMetaFlag || unwrapFlag {
+			if outputFormat := getOutputFormat(); outputFormat != config.OutputFormatJSON && outputFormat != config.OutputFormatYAML {
+				return fmt.Errorf("--with-meta and --unwrap require json or yaml output")
+			}
+			if withMetaFlag && (streamFlag || followFlag) {
+				return fmt.Errorf("--with-meta cannot be used with --stream or --follow, which print records without pagination")
+			}
+		}
+
+		if cmd.Flags().Changed("max-col-lines") {
+			if getOutputFormat() != config.OutputFormatTable {
+				return fmt.Errorf("--max-col-lines requires --output table")
+			}
+			if maxColLinesFlag < 1 {
+				return fmt.Errorf("--max-col-lines must be at least 1")
+			}
+		}
+
+		if summaryStatsFlag && getOutputFormat() != config.OutputFormatTable {
+			return fmt.Errorf("--summary-stats requires --output table")
+		}
+
+		if len(collectionsFlag) > 0 {
+			if filterPresetFlag != "" {
+				return fmt.Errorf("--filter-preset cannot be used with --collections")
+			}
+			if projectionFlag != "" {
+				return fmt.Errorf("--projection-preset cannot be used with --collections")
+			}
+			if seekFlag != "" {
+				return fmt.Errorf("--seek cannot be used with --collections")
+			}
+			if updatedSinceFlag != "" || followFlag {
+				return fmt.Errorf("--updated-since and --follow cannot be used with --collections")
+			}
+			outputFormat := getOutputFormat()
+			csvOptions, err := parseCSVOptions(cmd, outputFormat)
+			if err != nil {
+				return err
+			}
+			return listMultipleCollections(cmd, ctx, client, collectionsFlag, outputFormat, csvOptions)
+		}
+
+		collection := args[0]
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
 		options := &pocketbase.ListOptions{
 			Page:    pageFlag,
 			PerPage: limitFlag,
@@ -55,6 +232,121 @@ Examples:
 			Expand:  expandFlag,
 		}
 
+		if filterPresetFlag != "" {
+			if options.Filter, err = resolveFilterPreset(ctx, collection, filterPresetFlag); err != nil {
+				return err
+			}
+			utils.PrintDebug(fmt.Sprintf("Using filter preset '%s': %s", filterPresetFlag, options.Filter))
+		}
+
+		applyCollectionDefaults(cmd, ctx, collection, options)
+		if options.Filter, err = withFilterValues(options.Filter, filterValueFlags); err != nil {
+			return err
+		}
+		if options.Filter, err = withWhereConditions(options.Filter, whereFlags); err != nil {
+			return err
+		}
+		if projectionFlag != "" {
+			if cmd.Flags().Changed("fields") {
+				utils.PrintDebug(fmt.Sprintf("--fields overrides --projection-preset %s", projectionFlag))
+			} else if options.Fields, err = resolveProjectionPreset(client, collection, projectionFlag); err != nil {
+				return err
+			}
+		}
+		options.Fields = withExpandFields(options.Fields, options.Expand)
+
+		if seekFlag != "" {
+			if updatedSinceFlag != "" || followFlag {
+				return fmt.Errorf("--seek cannot be used with --updated-since or --follow")
+			}
+			if cmd.Flags().Changed("sort") || cmd.Flags().Changed("page") {
+				return fmt.Errorf("--seek pages by id; it cannot be used with --sort or --page")
+			}
+			if err := validateRecordID(seekFlag); err != nil {
+				return fmt.Errorf("invalid --seek: %w", err)
+			}
+			options.Filter = seekFilter(options.Filter, seekFlag)
+			options.Sort = "id"
+			if len(options.Fields) > 0 && !slices.Contains(options.Fields, "id") {
+				options.Fields = append(options.Fields, "id")
+			}
+		}
+
+		var feed *changeFeed
+		if updatedSinceFlag != "" || followFlag {
+			if cmd.Flags().Changed("sort") || sortDisplayFlag != "" {
+				return fmt.Errorf("--updated-since and --follow sort by updated; they cannot be used with --sort or --sort-display")
+			}
+			since := time.Now()
+			if updatedSinceFlag != "" {
+				if since, err = parseUpdatedSince(updatedSinceFlag, since); err != nil {
+					return fmt.Errorf("invalid --updated-since: %w", err)
+				}
+			}
+			feed = newChangeFeed(since)
+			options.Sort = "updated,id"
+		}
+
+		if followFlag {
+			if allFlag || streamFlag || cmd.Flags().Changed("page") || cmd.Flags().Changed("limit") {
+				return fmt.Errorf("--follow fetches every change itself; it cannot be used with --all, --stream, --page, or --limit")
+			}
+			if outputFormat := getOutputFormat(); outputFormat != config.OutputFormatJSON && outputFormat != config.OutputFormatNDJSON {
+				return fmt.Errorf("--follow only supports json or ndjson output (one record per line)")
+			}
+			if followIntervalFlag < time.Second {
+				return fmt.Errorf("--follow-interval must be at least 1s")
+			}
+			// The feed's position is tracked by id and updated.
+			if len(options.Fields) > 0 {
+				for _, field := range []string{"id", "updated"} {
+					if !slices.Contains(options.Fields, field) {
+						options.Fields = append(options.Fields, field)
+					}
+				}
+			}
+
+			out := utils.DataOutput()
+			return followRecords(out, client, collection, options, feed, followIntervalFlag)
+		}
+		if feed != nil {
+			options.Filter = feed.filter(options.Filter)
+		}
+
+		outputFormat := getOutputFormat()
+
+		// Only IDs are printed, so don't transfer anything else.
+		if outputFormat == config.OutputFormatKeys {
+			options.Fields = []string{"id"}
+		}
+		if idsFileFlag != "" && len(options.Fields) > 0 && !slices.Contains(options.Fields, "id") {
+			options.Fields = append(options.Fields, "id")
+		}
+
+		csvOptions, err := parseCSVOptions(cmd, outputFormat)
+		if err != nil {
+			return err
+		}
+
+		if streamFlag {
+			if !allFlag {
+				return fmt.Errorf("--stream requires --all")
+			}
+			if outputFormat != config.OutputFormatJSON && outputFormat != config.OutputFormatNDJSON {
+				return fmt.Errorf("--stream only supports json or ndjson output")
+			}
+			if sortDisplayFlag != "" {
+				return fmt.Errorf("--sort-display cannot be used with --stream")
+			}
+			return streamAllRecords(client, collection, options, outputFormat == config.OutputFormatNDJSON)
+		}
+
+		// One line per record needs no enclosing array, so --all can always stream
+		// ndjson unless the records have to be re-sorted first.
+		if allFlag && outputFormat == config.OutputFormatNDJSON && sortDisplayFlag == "" && idsFileFlag == "" {
+			return streamAllRecords(client, collection, options, true)
+		}
+
 		var result *pocketbase.RecordsList
 		if allFlag {
 			utils.PrintDebug(fmt.Sprintf("Listing all records from collection '%s' (filter='%s', sort='%s')",
@@ -79,18 +371,59 @@ Examples:
 			return fmt.Errorf("failed to list records: %w", err)
 		}
 
-		outputFormat := getOutputFormat()
+		if sortDisplayFlag != "" {
+			if err := utils.SortRecords(result.Items, sortDisplayFlag); err != nil {
+				return fmt.Errorf("invalid --sort-display: %w", err)
+			}
+		}
+
+		out := utils.DataOutput()
+
+		if listQueryFlag != "" {
+			value, err := utils.QueryPath(result, listQueryFlag)
+			if err != nil {
+				return fmt.Errorf("invalid --query: %w", err)
+			}
+			return utils.WriteQueryValue(out, value)
+		}
 
 		switch outputFormat {
 		case config.OutputFormatJSON:
-			return utils.OutputData(result, config.OutputFormatJSON)
+			err = utils.OutputDataTo(out, shapeListResult(result), config.OutputFormatJSON)
 		case config.OutputFormatYAML:
-			return utils.OutputData(result, config.OutputFormatYAML)
+			err = utils.OutputDataTo(out, shapeListResult(result), config.OutputFormatYAML)
 		case config.OutputFormatTable:
-			return displayListTable(result, collection)
+			err = displayListTable(out, result, collection)
+		case config.OutputFormatHTML:
+			err = utils.OutputDataTo(out, result.Items, config.OutputFormatHTML)
+		case config.OutputFormatCSV:
+			err = utils.OutputCSV(out, result.Items, csvOptions)
+		case config.OutputFormatKeys:
+			err = writeRecordIDs(out, result.Records())
+		case config.OutputFormatNDJSON:
+			err = utils.OutputDataTo(out, result.Items, config.OutputFormatNDJSON)
 		default:
 			return fmt.Errorf("unsupported output format: %s", outputFormat)
 		}
+		if err != nil {
+			return err
+		}
+
+		if path := outputFileName(); path != "" {
+			fmt.Fprintf(os.Stderr, "Wrote %d record(s) to %s\n", len(result.Items), path)
+		}
+		if idsFileFlag != "" {
+			if err := writeIDsFile(idsFileFlag, result.Records()); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d record ID(s) to %s\n", len(result.Items), idsFileFlag)
+		}
+		if !allFlag && options.Sort == "id" {
+			if next := nextSeekID(result); next != "" {
+				fmt.Fprintf(os.Stderr, "Next page: --seek %s\n", next)
+			}
+		}
+		return nil
 	},
 }
 
@@ -99,13 +432,314 @@ func init() {
 	listCmd.Flags().IntVar(&limitFlag, "limit", 30, "Maximum number of records to return")
 	listCmd.Flags().BoolVar(&allFlag, "all", false, "Fetch all records across all pages (cannot be used with --page/--limit)")
 	listCmd.Flags().StringVar(&filterFlag, "filter", "", "PocketBase filter expression (e.g., 'published=true && title~\"test\"')")
+	listCmd.Flags().StringVar(&filterPresetFlag, "filter-preset", "", "Use a filter saved with 'pb collections filter save'")
+	listCmd.Flags().StringArrayVar(&filterValueFlags, "filter-value", nil, "Only records where field equals value exactly (field=value, repeatable; quotes are escaped)")
+	listCmd.Flags().StringArrayVar(&whereFlags, "where", nil, "Simple condition such as status=paid, 'total>=10', or title~draft (repeatable; values are quoted for you)")
 	listCmd.Flags().StringVar(&sortFlag, "sort", "", "Sort expression (e.g., 'title', '-created', 'title,-updated')")
 	listCmd.Flags().StringSliceVar(&fieldsFlag, "fields", nil, "Specific fields to return (comma-separated)")
+	listCmd.Flags().StringVar(&projectionFlag, "projection-preset", "", "Fields to return by preset: minimal (id and presentable fields), display, or full; --fields overrides it")
+	listCmd.Flags().StringVar(&seekFlag, "seek", "", "Continue after this record ID (filters id > ID and sorts by id) for stable paging")
 	listCmd.Flags().StringSliceVar(&expandFlag, "expand", nil, "Relations to expand (comma-separated)")
+	listCmd.Flags().StringSliceVar(&collectionsFlag, "collections", nil, "List from several collections at once (comma-separated) instead of one")
+	listCmd.Flags().StringVar(&sortDisplayFlag, "sort-display", "", "Re-sort fetched records client-side by this field before display ('-field' for descending)")
+	listCmd.Flags().StringVar(&idsFileFlag, "ids-file", "", "Also write the IDs of the listed records to this file, one per line")
+	listCmd.Flags().StringVar(&updatedSinceFlag, "updated-since", "", "Only records updated after this time: a duration back from now (15m, 7d), a date, or a datetime")
+	listCmd.Flags().BoolVar(&followFlag, "follow", false, "Keep polling and print new or changed records as JSON lines as they appear")
+	listCmd.Flags().DurationVar(&followIntervalFlag, "follow-interval", 5*time.Second, "How often --follow polls for changes")
+	listCmd.Flags().BoolVar(&streamFlag, "stream", false, "With --all and json output, write records incrementally as pages arrive")
+	listCmd.Flags().BoolVar(&noHeaderFlag, "no-header", false, "Omit the header row in csv output")
+	listCmd.Flags().StringVar(&delimiterFlag, "delimiter", ",", "Field delimiter for csv output (a single character, or '\\t' for tab)")
+	listCmd.Flags().BoolVar(&truncateIDsFlag, "truncate-ids", false, "Shorten record IDs in table output (e.g. abcd…mno); json, yaml, and csv keep full IDs")
+	listCmd.Flags().BoolVar(&withMetaFlag, "with-meta", false, "JSON/YAML: print {data: [...], meta: {...}} instead of the PocketBase envelope")
+	listCmd.Flags().BoolVar(&unwrapFlag, "unwrap", false, "JSON/YAML: print only the array of records, without pagination fields")
+	listCmd.MarkFlagsMutuallyExclusive("with-meta", "unwrap")
+	listCmd.Flags().StringVar(&listQueryFlag, "query", "", "Print only the value at this dotted path into the result (e.g. items.0.id)")
+	listCmd.Flags().IntVar(&maxColLinesFlag, "max-col-lines", 1, "Wrap long table values over up to this many lines instead of truncating them")
+	listCmd.Flags().BoolVar(&summaryStatsFlag, "summary-stats", false, "Print min/max/avg/sum of the listed rows' numeric columns below the table")
+	listCmd.Flags().BoolVar(&humanizeFlag, "humanize", false, "Show counts with thousands separators in table output (e.g. 1,250,000)")
 
 	// --all supersedes manual pagination; make the conflict explicit rather than silent.
 	listCmd.MarkFlagsMutuallyExclusive("all", "page")
 	listCmd.MarkFlagsMutuallyExclusive("all", "limit")
+	listCmd.MarkFlagsMutuallyExclusive("filter", "filter-preset")
+}
+
+// writeRecordIDs writes one record ID per line, for feeding into other commands.
+func writeRecordIDs(w io.Writer, records []pocketbase.Record) error {
+	bw := bufio.NewWriter(w)
+	for _, record := range records {
+		id := record.GetID()
+		if id == "" {
+			return fmt.Errorf("record has no string id field")
+		}
+		if _, err := fmt.Fprintln(bw, id); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// writeIDsFile writes the IDs of records to the file at path, one per line,
+// replacing any existing file.
+func writeIDsFile(path string, records []pocketbase.Record) error {
+	f, err := utils.CreateOutputFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to create --ids-file: %w", err)
+	}
+	if err := writeRecordIDs(f, records); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write --ids-file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write --ids-file: %w", err)
+	}
+	return nil
+}
+
+// applyCollectionDefaults fills filter, sort, and fields from the context's defaults
+// for collection, but only where the corresponding flag was not given.
+func applyCollectionDefaults(cmd *cobra.Command, ctx *config.Context, collection string, options *pocketbase.ListOptions) {
+	defaults, exists := ctx.PocketBase.CollectionDefaults[collection]
+	if !exists {
+		return
+	}
+
+	var applied []string
+	if defaults.Filter != "" && !cmd.Flags().Changed("filter") && !cmd.Flags().Changed("filter-preset") {
+		options.Filter = defaults.Filter
+		applied = append(applied, fmt.Sprintf("filter=%q", defaults.Filter))
+	}
+	if defaults.Sort != "" && !cmd.Flags().Changed("sort") {
+		options.Sort = defaults.Sort
+		applied = append(applied, fmt.Sprintf("sort=%q", defaults.Sort))
+	}
+	if len(defaults.Fields) > 0 && !cmd.Flags().Changed("fields") && !cmd.Flags().Changed("projection-preset") {
+		options.Fields = defaults.Fields
+		applied = append(applied, fmt.Sprintf("fields=%s", strings.Join(defaults.Fields, ",")))
+	}
+
+	if len(applied) > 0 {
+		// stderr, so piped json/csv output stays parseable.
+		fmt.Fprintf(os.Stderr, "Using context defaults for '%s': %s\n", collection, strings.Join(applied, " "))
+	}
+}
+
+// seekFilter restricts base to records after lastID in id order, for --seek.
+func seekFilter(base, lastID string) string {
+	position := fmt.Sprintf("id > %q", lastID)
+	if strings.TrimSpace(base) == "" {
+		return position
+	}
+	return fmt.Sprintf("(%s) && %s", base, position)
+}
+
+// nextSeekID returns the ID to pass to --seek for the page after result, or ""
+// when result is the last page.
+func nextSeekID(result *pocketbase.RecordsList) string {
+	if len(result.Items) == 0 || result.Page >= result.TotalPages {
+		return ""
+	}
+	return result.Records()[len(result.Items)-1].GetID()
+}
+
+// Projection presets for list --projection-preset.
+const (
+	projectionMinimal = "minimal"
+	projectionDisplay = "display"
+	projectionFull    = "full"
+)
+
+// resolveProjectionPreset turns a projection preset into the fields to request.
+// full requests every field (nil). minimal needs the collection schema; when it
+// can't be read, display is used instead.
+func resolveProjectionPreset(client *pocketbase.Client, collection, preset string) ([]string, error) {
+	switch strings.ToLower(preset) {
+	case projectionFull:
+		return nil, nil
+	case projectionDisplay:
+		return displayProjection(), nil
+	case projectionMinimal:
+		schema, err := client.GetCollectionSchema(collection)
+		if err != nil {
+			utils.PrintDebug(fmt.Sprintf("Could not read schema for '%s': %v", collection, err))
+			utils.PrintWarning("could not read the collection schema; using the display projection instead of minimal")
+			return displayProjection(), nil
+		}
+		return minimalProjection(schema), nil
+	default:
+		return nil, fmt.Errorf("unknown --projection-preset %q (use minimal, display, or full)", preset)
+	}
+}
+
+// displayProjection returns id and the fields used to name records.
+func displayProjection() []string {
+	return append([]string{"id"}, pocketbase.DisplayNameFields...)
+}
+
+// minimalProjection returns id and the schema's presentable fields, or the
+// schema's display-name fields when none is marked presentable.
+func minimalProjection(schema *pocketbase.Collection) []string {
+	fields := []string{"id"}
+	for _, field := range schema.Fields {
+		if field.Presentable && field.Name != "id" {
+			fields = append(fields, field.Name)
+		}
+	}
+	if len(fields) > 1 {
+		return fields
+	}
+	for _, field := range schema.Fields {
+		if slices.Contains(pocketbase.DisplayNameFields, field.Name) {
+			fields = append(fields, field.Name)
+		}
+	}
+	return fields
+}
+
+// parseCSVOptions builds the csv renderer options from --delimiter and --no-header,
+// rejecting them when the output format isn't csv.
+func parseCSVOptions(cmd *cobra.Command, outputFormat string) (utils.CSVOptions, error) {
+	if outputFormat != config.OutputFormatCSV {
+		for _, name := range []string{"delimiter", "no-header"} {
+			if cmd.Flags().Changed(name) {
+				return utils.CSVOptions{}, fmt.Errorf("--%s requires --output csv", name)
+			}
+		}
+		return utils.CSVOptions{}, nil
+	}
+
+	delimiter := delimiterFlag
+	if delimiter == `\t` || delimiter == "tab" {
+		delimiter = "\t"
+	}
+	runes := []rune(delimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return utils.CSVOptions{}, fmt.Errorf("invalid --delimiter %q: must be a single character other than a quote or newline", delimiterFlag)
+	}
+
+	return utils.CSVOptions{Delimiter: runes[0], NoHeader: noHeaderFlag}, nil
+}
+
+// listMeta is the pagination metadata printed by --with-meta.
+type listMeta struct {
+	Page       int `json:"page" yaml:"page"`
+	PerPage    int `json:"perPage" yaml:"perPage"`
+	TotalItems int `json:"totalItems" yaml:"totalItems"`
+	TotalPages int `json:"totalPages" yaml:"totalPages"`
+}
+
+// listWithMeta is the --with-meta output shape.
+type listWithMeta struct {
+	Data []map[string]interface{} `json:"data" yaml:"data"`
+	Meta listMeta                 `json:"meta" yaml:"meta"`
+}
+
+// shapeListResult returns result in the shape chosen by --with-meta or --unwrap,
+// or unchanged (PocketBase's envelope) by default.
+func shapeListResult(result *pocketbase.RecordsList) interface{} {
+	items := result.Items
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+
+	switch {
+	case withMetaFlag:
+		return listWithMeta{
+			Data: items,
+			Meta: listMeta{
+				Page:       result.Page,
+				PerPage:    result.PerPage,
+				TotalItems: result.TotalItems,
+				TotalPages: result.TotalPages,
+			},
+		}
+	case unwrapFlag:
+		return items
+	default:
+		return result
+	}
+}
+
+// outputFileName returns the path of the global --output-file list is writing to,
+// or "" when its output goes to stdout.
+func outputFileName() string {
+	if f, ok := utils.DataOutput().(*os.File); ok && f != os.Stdout {
+		return f.Name()
+	}
+	return ""
+}
+
+// streamAllRecords writes every matching record as a JSON array, or as one line of
+// JSON per record when ndjson is set, emitting each page as soon as it is fetched
+// so at most one page is held in memory.
+func streamAllRecords(client *pocketbase.Client, collection string, options *pocketbase.ListOptions, ndjson bool) error {
+	utils.PrintDebug(fmt.Sprintf("Streaming all records from collection '%s' (filter='%s', sort='%s')",
+		collection, options.Filter, options.Sort))
+
+	out := utils.DataOutput()
+
+	w := bufio.NewWriter(out)
+	count := 0
+
+	if !ndjson {
+		if _, err := w.WriteString("["); err != nil {
+			return err
+		}
+	}
+	err := client.EachRecordPage(collection, options, func(page *pocketbase.RecordsList) error {
+		for _, item := range page.Items {
+			if ndjson {
+				if err := utils.WriteJSONLine(w, item); err != nil {
+					return err
+				}
+				count++
+				continue
+			}
+			data, err := json.MarshalIndent(item, "  ", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal record: %w", err)
+			}
+			sep := ",\n  "
+			if count == 0 {
+				sep = "\n  "
+			}
+			if _, err := w.WriteString(sep); err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			count++
+		}
+		return w.Flush()
+	})
+	if err != nil {
+		// The array is left unterminated so a partial export can't pass for a complete one.
+		w.Flush()
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			return fmt.Errorf("failed to stream records after %d record(s)", count)
+		}
+		return fmt.Errorf("failed to stream records after %d record(s): %w", count, err)
+	}
+
+	if !ndjson {
+		closing := "]\n"
+		if count > 0 {
+			closing = "\n]\n"
+		}
+		if _, err := w.WriteString(closing); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if path := outputFileName(); path != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %d record(s) to %s\n", count, path)
+	}
+	return nil
 }
 
 // validatePaginationOptions validates pagination parameters
@@ -0,0 +1,113 @@
+package collections
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+// parseUpdatedSince resolves an --updated-since value: a duration back from now
+// (e.g. 15m, 24h, 7d), a date (2024-01-02), or a PocketBase/RFC 3339 datetime.
+func parseUpdatedSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if d, err := utils.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration must not be negative")
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := pocketbase.ParseTime(value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("expected a duration (e.g. 15m, 7d), a date (2024-01-02), or a datetime (2024-01-02 15:04:05Z), got %q", value)
+}
+
+// changeFeed tracks how far a feed of records ordered by `updated` has been read.
+// PocketBase timestamps have millisecond precision, so after the first poll the
+// feed asks for `updated >= since` and skips the IDs already emitted at exactly
+// since; a record updated in the same millisecond as the last poll is not lost,
+// and none is printed twice.
+type changeFeed struct {
+	since       string
+	seenAtSince map[string]bool
+}
+
+// newChangeFeed starts a feed of records updated strictly after since.
+func newChangeFeed(since time.Time) *changeFeed {
+	return &changeFeed{since: pocketbase.FormatTime(since), seenAtSince: map[string]bool{}}
+}
+
+// filter combines the user's filter with the feed's position.
+func (f *changeFeed) filter(base string) string {
+	op := ">"
+	if len(f.seenAtSince) > 0 {
+		op = ">="
+	}
+	position := fmt.Sprintf("updated %s %q", op, f.since)
+	if strings.TrimSpace(base) == "" {
+		return position
+	}
+	return fmt.Sprintf("(%s) && %s", base, position)
+}
+
+// accept reports whether record is new to the feed and advances the position.
+// Records must be offered in ascending `updated` order.
+func (f *changeFeed) accept(record pocketbase.Record) bool {
+	updated, id := record.GetString("updated"), record.GetID()
+	switch {
+	case updated > f.since:
+		f.since = updated
+		f.seenAtSince = map[string]bool{id: true}
+		return true
+	case updated == f.since && !f.seenAtSince[id]:
+		f.seenAtSince[id] = true
+		return true
+	default:
+		return false
+	}
+}
+
+// followRecords polls for records updated since the feed's position and writes
+// each new or changed one as a line of JSON, until interrupted.
+func followRecords(w io.Writer, client *pocketbase.Client, collection string, options *pocketbase.ListOptions, feed *changeFeed, interval time.Duration) error {
+	baseFilter := options.Filter
+	encoder := json.NewEncoder(w)
+
+	fmt.Fprintf(os.Stderr, "Following changes to '%s' since %s (every %s, Ctrl+C to stop)\n", collection, feed.since, interval)
+
+	for {
+		poll := *options
+		poll.Filter = feed.filter(baseFilter)
+		utils.PrintDebug(fmt.Sprintf("Polling '%s' with filter '%s'", collection, poll.Filter))
+
+		err := client.EachRecordPage(collection, &poll, func(page *pocketbase.RecordsList) error {
+			for _, record := range page.Records() {
+				if !feed.accept(record) {
+					continue
+				}
+				if err := encoder.Encode(record); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				return fmt.Errorf("failed to poll for changes")
+			}
+			return fmt.Errorf("failed to poll for changes: %w", err)
+		}
+
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,61 @@
+package collections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/pocketbase"
+)
+
+// TestParseUpdatedSince checks the duration, date, and datetime forms.
+func TestParseUpdatedSince(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseUpdatedSince("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-90*time.Minute), got)
+
+	got, err = parseUpdatedSince("7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, -7), got)
+
+	got, err = parseUpdatedSince("2024-05-02", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), got)
+
+	got, err = parseUpdatedSince("2024-05-02 10:30:00.000Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 2, 10, 30, 0, 0, time.UTC), got)
+
+	_, err = parseUpdatedSince("yesterday", now)
+	assert.Error(t, err)
+	_, err = parseUpdatedSince("-1h", now)
+	assert.Error(t, err)
+}
+
+// TestChangeFeed checks that polling never repeats a record, including ones that
+// share the last seen millisecond, and still picks up later changes.
+func TestChangeFeed(t *testing.T) {
+	feed := newChangeFeed(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, `updated > "2024-01-01 00:00:00.000Z"`, feed.filter(""))
+
+	rec := func(id, updated string) pocketbase.Record {
+		return pocketbase.Record{"id": id, "updated": updated}
+	}
+
+	// First poll.
+	assert.True(t, feed.accept(rec("a", "2024-01-02 00:00:00.000Z")))
+	assert.True(t, feed.accept(rec("b", "2024-01-03 00:00:00.000Z")))
+	assert.Equal(t, `(published=true) && updated >= "2024-01-03 00:00:00.000Z"`, feed.filter("published=true"))
+
+	// Second poll returns b again plus c, written in the same millisecond, and d.
+	assert.False(t, feed.accept(rec("b", "2024-01-03 00:00:00.000Z")))
+	assert.True(t, feed.accept(rec("c", "2024-01-03 00:00:00.000Z")))
+	assert.True(t, feed.accept(rec("d", "2024-01-04 00:00:00.000Z")))
+
+	// Later, a is edited again and shows up once more.
+	assert.False(t, feed.accept(rec("d", "2024-01-04 00:00:00.000Z")))
+	assert.True(t, feed.accept(rec("a", "2024-01-05 00:00:00.000Z")))
+}
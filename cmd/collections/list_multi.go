@@ -0,0 +1,186 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+// collectionColumn is added to each record in combined multi-collection output so
+// rows can be traced back to their collection.
+const collectionColumn = "_collection"
+
+// collectionListResult is the outcome of listing one collection of a --collections run.
+type collectionListResult struct {
+	collection string
+	records    *pocketbase.RecordsList
+	err        error
+}
+
+// listMultipleCollections runs the same list query against each collection and
+// renders the results together. Collections that fail (e.g. don't exist) are
+// reported and skipped; the command only fails when none could be listed.
+func listMultipleCollections(cmd *cobra.Command, ctx *config.Context, client *pocketbase.Client, collections []string, outputFormat string, csvOptions utils.CSVOptions) error {
+	if streamFlag {
+		return fmt.Errorf("--stream cannot be used with --collections")
+	}
+	if outputFormat == config.OutputFormatKeys {
+		return fmt.Errorf("--output keys cannot be used with --collections (IDs are only unique per collection)")
+	}
+
+	results := make([]collectionListResult, len(collections))
+	for i, name := range collections {
+		resolved, err := resolveCollectionName(client, name)
+		if err != nil {
+			return err
+		}
+		results[i].collection = resolved
+	}
+
+	// Each collection gets its own options so context defaults apply per collection.
+	optionsFor := make([]*pocketbase.ListOptions, len(results))
+	for i := range results {
+		options := &pocketbase.ListOptions{
+			Page:    pageFlag,
+			PerPage: limitFlag,
+			Filter:  filterFlag,
+			Sort:    sortFlag,
+			Fields:  fieldsFlag,
+			Expand:  expandFlag,
+		}
+		applyCollectionDefaults(cmd, ctx, results[i].collection, options)
+		filter, err := withFilterValues(options.Filter, filterValueFlags)
+		if err != nil {
+			return err
+		}
+		if filter, err = withWhereConditions(filter, whereFlags); err != nil {
+			return err
+		}
+		options.Filter = filter
+		options.Fields = withExpandFields(options.Fields, options.Expand)
+		if !allFlag {
+			if err := validatePaginationOptions(options); err != nil {
+				return fmt.Errorf("invalid pagination options: %w", err)
+			}
+		}
+		optionsFor[i] = options
+	}
+
+	// A token refresh rewrites the shared context, so --auto-reauth lists one
+	// collection at a time.
+	workers := len(results)
+	if autoReauthFlag {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			utils.PrintDebug(fmt.Sprintf("Listing records from collection '%s' (filter='%s', sort='%s')",
+				results[i].collection, optionsFor[i].Filter, optionsFor[i].Sort))
+			if allFlag {
+				results[i].records, results[i].err = client.ListAllRecords(results[i].collection, optionsFor[i])
+			} else {
+				results[i].records, results[i].err = client.ListRecords(results[i].collection, optionsFor[i])
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var listed []collectionListResult
+	for _, result := range results {
+		if result.err == nil {
+			listed = append(listed, result)
+			continue
+		}
+		if pbErr, ok := result.err.(*pocketbase.PocketBaseError); ok {
+			if pbErr.IsNotFoundError() {
+				utils.PrintWarning(fmt.Sprintf("collection '%s' not found; skipping", result.collection))
+			} else {
+				utils.PrintWarning(fmt.Sprintf("skipping collection '%s': %s", result.collection, pbErr.GetFriendlyMessage()))
+			}
+			continue
+		}
+		utils.PrintWarning(fmt.Sprintf("skipping collection '%s': %v", result.collection, result.err))
+	}
+	if len(listed) == 0 {
+		return fmt.Errorf("failed to list records from any of the given collections")
+	}
+
+	if sortDisplayFlag != "" {
+		for _, result := range listed {
+			if err := utils.SortRecords(result.records.Items, sortDisplayFlag); err != nil {
+				return fmt.Errorf("invalid --sort-display: %w", err)
+			}
+		}
+	}
+
+	out := utils.DataOutput()
+
+	var err error
+	switch outputFormat {
+	case config.OutputFormatJSON, config.OutputFormatYAML:
+		byCollection := make(map[string]interface{}, len(listed))
+		for _, result := range listed {
+			byCollection[result.collection] = shapeListResult(result.records)
+		}
+		err = utils.OutputDataTo(out, byCollection, outputFormat)
+	case config.OutputFormatTable:
+		for i, result := range listed {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			if err = displayListTable(out, result.records, result.collection); err != nil {
+				break
+			}
+		}
+	case config.OutputFormatHTML:
+		err = utils.OutputDataTo(out, combineListResults(listed), config.OutputFormatHTML)
+	case config.OutputFormatCSV:
+		err = utils.OutputCSV(out, combineListResults(listed), csvOptions)
+	case config.OutputFormatNDJSON:
+		err = utils.OutputDataTo(out, combineListResults(listed), config.OutputFormatNDJSON)
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if path := outputFileName(); path != "" {
+		total := 0
+		for _, result := range listed {
+			total += len(result.records.Items)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d record(s) from %d collection(s) to %s\n", total, len(listed), path)
+	}
+	return nil
+}
+
+// combineListResults flattens the listed records into one set of rows, tagging each
+// with its collection in the _collection column.
+func combineListResults(listed []collectionListResult) []map[string]interface{} {
+	var combined []map[string]interface{}
+	for _, result := range listed {
+		for _, item := range result.records.Items {
+			item[collectionColumn] = result.collection
+			combined = append(combined, item)
+		}
+	}
+	// Each collection was sorted on its own; order the combined rows as one table.
+	if sortDisplayFlag != "" {
+		utils.SortRecords(combined, sortDisplayFlag)
+	}
+	return combined
+}
@@ -0,0 +1,107 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var listServerFlag bool
+
+// serverCollection is one row of 'pb collections --list'.
+type serverCollection struct {
+	Name       string `json:"name" yaml:"name"`
+	Type       string `json:"type" yaml:"type"`
+	System     bool   `json:"system" yaml:"system"`
+	Configured bool   `json:"configured" yaml:"configured"`
+}
+
+// serverCollections pairs the instance's collections with whether the context
+// has list defaults stored for them.
+func serverCollections(collections []pocketbase.Collection, ctx *config.Context) []serverCollection {
+	rows := make([]serverCollection, 0, len(collections))
+	for _, c := range collections {
+		_, configured := ctx.PocketBase.CollectionDefaults[c.Name]
+		rows = append(rows, serverCollection{
+			Name:       c.Name,
+			Type:       c.Type,
+			System:     c.System,
+			Configured: configured,
+		})
+	}
+	return rows
+}
+
+// listServerCollections prints every collection on the instance, for
+// discovering names to pass to the other collections commands.
+func listServerCollections() error {
+	ctx, err := validateActiveContext()
+	if err != nil {
+		return err
+	}
+
+	client := createPocketBaseClient(ctx)
+	collections, err := client.GetCollections()
+	if err != nil {
+		if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+			if pbErr.StatusCode == 401 || pbErr.StatusCode == 403 {
+				utils.PrintError(fmt.Errorf("listing collections requires superuser access"))
+				fmt.Fprintln(os.Stderr, "\nSuggestion: authenticate as a superuser with 'pb auth --collection _superusers'")
+				return fmt.Errorf("failed to list collections")
+			}
+			utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+			if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+				fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+			}
+			return fmt.Errorf("failed to list collections")
+		}
+		return fmt.Errorf("failed to list collections: %w", err)
+	}
+
+	rows := serverCollections(collections, ctx)
+
+	outputFormat := getOutputFormat()
+	switch outputFormat {
+	case config.OutputFormatJSON, config.OutputFormatYAML:
+		return utils.OutputData(rows, outputFormat)
+	case config.OutputFormatTable:
+	default:
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	out := utils.DataOutput()
+	if len(rows) == 0 {
+		fmt.Fprintln(out, "No collections found.")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Collections on '%s' (%d):\n\n", ctx.Name, len(rows))
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"NAME", "TYPE", "SYSTEM", "CONFIGURED"})
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetRowSeparator("")
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("  ")
+	table.SetAutoFormatHeaders(false)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for _, row := range rows {
+		table.Append([]string{row.Name, row.Type, yesNo(row.System), yesNo(row.Configured)})
+	}
+	table.Render()
+
+	fmt.Fprintf(os.Stderr, "\nCONFIGURED collections have list defaults in this context; add some with 'pb context collections set-default'.\n")
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -1,17 +1,24 @@
 package collections
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"pb-cli/internal/config"
 	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
 )
 
-var outputFlag string
+var (
+	outputFlag          string
+	autoReauthFlag      bool
+	fuzzyCollectionFlag bool
+)
 
 // CollectionsCmd represents the collections command
 var CollectionsCmd = &cobra.Command{
@@ -24,14 +31,22 @@ Usage Pattern:
   pb collections <action> <collection> [args] [flags]
 
 Actions:
-  list     List records from a collection with filtering and pagination
-  get      Get a single record by ID
-  create   Create a new record from JSON data or file
-  update   Update an existing record with JSON data or file
-  delete   Delete a record with confirmation
+  list           List records from a collection with filtering and pagination
+  get            Get a single record by ID
+  count          Count records, optionally matching a filter
+  create         Create a new record from JSON data or file
+  update         Update an existing record with JSON data or file
+  upload         Upload files to a record's file fields
+  file           Download a file stored in a record's file field
+  delete         Delete a record, or records matching --filter, with confirmation
+  copy           Copy a record to the same collection in another context
+  move           Copy a record to another context, then delete the original
+  validate-data  Check a create/update payload against the schema offline
+  filter         Save and reuse named filter presets
 
 Any collection your authenticated user can access works directly — no need to
-register collections first. Use 'pb schema' to see which collections exist.
+register collections first. Use 'pb collections --list' (or 'pb schema') to see
+which collections exist.
 
 Data for 'create' and 'update' actions can be provided in one of three ways:
   1. As a JSON string argument
@@ -39,31 +54,51 @@ Data for 'create' and 'update' actions can be provided in one of three ways:
   3. Piped from stdin
 
 Examples:
+  pb collections --list
   pb collections list posts
   pb collections list posts --filter 'published=true' --sort '-created'
+  pb collections list posts --all --auto-reauth
   pb collections get users user_abc123 --expand profile
+  pb collections count posts --filter 'published=true'
   pb collections create posts '{"title":"My Post","content":"Hello world"}'
   pb collections update posts post_123 '{"published":true}'
   pb collections delete users user_456 --force
+  pb collections copy settings set_123 --to-context prod
+  pb collections validate-data posts --file post.json
 
   # Short alias
   pb c list posts
   pb c get posts post_123`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return fmt.Errorf("missing subcommand. Available: list, get, create, update, delete")
+		if listServerFlag {
+			return listServerCollections()
+		}
+		return fmt.Errorf("missing subcommand. Available: list, get, count, create, update, upload, file, delete, copy, move, validate-data, filter (or --list to see the server's collections)")
 	},
 }
 
 var configManager *config.Manager
 
 func init() {
-	CollectionsCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml|table)")
+	CollectionsCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml|table; list also html|csv|keys|ndjson; get also csv|ndjson; get/create/update also id)")
+	CollectionsCmd.PersistentFlags().BoolVar(&fuzzyCollectionFlag, "fuzzy-collection", false, "Resolve singular/plural collection names (e.g. post -> posts) when there is no exact match")
+	CollectionsCmd.PersistentFlags().BoolVar(&autoReauthFlag, "auto-reauth", false, "Refresh the auth token before it expires mid-run (for long-running operations)")
+
+	CollectionsCmd.Flags().BoolVar(&listServerFlag, "list", false, "List the collections that exist on the server")
 
 	CollectionsCmd.AddCommand(listCmd)
 	CollectionsCmd.AddCommand(getCmd)
+	CollectionsCmd.AddCommand(countCmd)
 	CollectionsCmd.AddCommand(createCmd)
 	CollectionsCmd.AddCommand(updateCmd)
+	CollectionsCmd.AddCommand(editCmd)
+	CollectionsCmd.AddCommand(uploadCmd)
+	CollectionsCmd.AddCommand(fileCmd)
 	CollectionsCmd.AddCommand(deleteCmd)
+	CollectionsCmd.AddCommand(copyCmd)
+	CollectionsCmd.AddCommand(moveCmd)
+	CollectionsCmd.AddCommand(validateDataCmd)
+	CollectionsCmd.AddCommand(filterCmd)
 }
 
 // SetConfigManager sets the configuration manager for the collections commands
@@ -79,6 +114,13 @@ func getOutputFormat() string {
 	return config.Global.OutputFormat
 }
 
+// failureReportFormat is the format for the failure report of a batch command.
+// Only an explicit -o json or ndjson switches it to JSON; the configured default
+// output format keeps the readable list.
+func failureReportFormat() string {
+	return outputFlag
+}
+
 // validateConfigManager ensures the config manager is available
 func validateConfigManager() error {
 	if configManager == nil {
@@ -113,17 +155,267 @@ func validateActiveContext() (*config.Context, error) {
 	return ctx, nil
 }
 
+// validateNamedContext loads a context other than the active one and ensures it is
+// authenticated, for commands that talk to two environments.
+func validateNamedContext(name string) (*config.Context, error) {
+	if err := validateConfigManager(); err != nil {
+		return nil, err
+	}
+
+	ctx, err := configManager.LoadContext(name)
+	if err != nil {
+		return nil, fmt.Errorf("context '%s' not found. Use 'pb context list' to see available contexts", name)
+	}
+
+	if ctx.PocketBase.AuthToken == "" {
+		return nil, fmt.Errorf("context '%s' is not authenticated. Run 'pb context select %s' and 'pb auth'", name, name)
+	}
+
+	if err := pocketbase.EnsureFreshAuth(ctx, configManager); err != nil {
+		return nil, err
+	}
+
+	if !pocketbase.IsAuthValid(ctx) {
+		return nil, fmt.Errorf("authentication for context '%s' has expired. Run 'pb context select %s' and 'pb auth'", name, name)
+	}
+
+	return ctx, nil
+}
+
 // createPocketBaseClient creates an authenticated PocketBase client from context
 func createPocketBaseClient(ctx *config.Context) *pocketbase.Client {
-	return pocketbase.NewClientFromContext(ctx)
+	client := pocketbase.NewClientFromContext(ctx)
+	if autoReauthFlag {
+		client.EnableAutoReauth(ctx, configManager)
+	}
+	return client
+}
+
+// quietRequested reports whether the running command was given --quiet. Each
+// command binds its own variable, and only the running command's can be set.
+func quietRequested() bool {
+	return createQuietFlag || updateQuietFlag || editQuietFlag || copyQuietFlag ||
+		quietFlag || fileQuietFlag || uploadQuietFlag
+}
+
+// resolveCollectionName applies --fuzzy-collection: when name has no exact match on
+// the instance, a single singular/plural variant that does exist is used instead.
+// Listing collections needs superuser auth, so without it name is used unchanged.
+func resolveCollectionName(client *pocketbase.Client, name string) (string, error) {
+	if !fuzzyCollectionFlag {
+		return name, nil
+	}
+
+	collections, err := client.GetCollections()
+	if err != nil {
+		utils.PrintWarning(fmt.Sprintf("--fuzzy-collection: could not list collections (%v); using '%s' as given", err, name))
+		return name, nil
+	}
+
+	available := make([]string, 0, len(collections))
+	for _, c := range collections {
+		available = append(available, c.Name)
+	}
+
+	matches := matchCollectionName(name, available)
+	switch len(matches) {
+	case 0:
+		// Let the request fail with the server's not-found error.
+		return name, nil
+	case 1:
+		if matches[0] != name {
+			// stderr, so it never mixes with data; with --quiet, only under --debug.
+			if quietRequested() {
+				utils.PrintDebug(fmt.Sprintf("Using collection '%s' for '%s'", matches[0], name))
+			} else {
+				fmt.Fprintf(os.Stderr, "Using collection '%s' for '%s'\n", matches[0], name)
+			}
+		}
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("collection '%s' is ambiguous; did you mean one of: %s", name, strings.Join(matches, ", "))
+	}
+}
+
+// matchCollectionName returns name if it is in available, otherwise every available
+// collection that is a simple singular or plural form of name.
+func matchCollectionName(name string, available []string) []string {
+	for _, candidate := range available {
+		if candidate == name {
+			return []string{name}
+		}
+	}
+
+	var matches []string
+	variants := collectionNameVariants(name)
+	for _, candidate := range available {
+		for _, variant := range variants {
+			if candidate == variant {
+				matches = append(matches, candidate)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// collectionNameVariants returns naive English singular and plural forms of name:
+// post <-> posts, category <-> categories, box <-> boxes.
+func collectionNameVariants(name string) []string {
+	var variants []string
+
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		variants = append(variants, strings.TrimSuffix(name, "ies")+"y")
+	case strings.HasSuffix(name, "es"):
+		variants = append(variants, strings.TrimSuffix(name, "es"), strings.TrimSuffix(name, "s"))
+	case strings.HasSuffix(name, "s"):
+		variants = append(variants, strings.TrimSuffix(name, "s"))
+	}
+
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		variants = append(variants, strings.TrimSuffix(name, "y")+"ies")
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		variants = append(variants, name+"es")
+	default:
+		variants = append(variants, name+"s")
+	}
+
+	return variants
+}
+
+// withExpandFields adds an expand.<relation> projection for every expanded relation
+// not already covered by fields. PocketBase applies the fields projection to the
+// whole response, expand included, so --fields title --expand author would otherwise
+// silently drop the expanded author. Nested expands (author.profile) are covered by
+// their top-level relation. Fields are returned unchanged when no projection is set.
+func withExpandFields(fields, expand []string) []string {
+	if len(fields) == 0 || len(expand) == 0 {
+		return fields
+	}
+
+	result := append([]string(nil), fields...)
+	covered := func(relation string) bool {
+		for _, field := range result {
+			field = strings.TrimSpace(field)
+			if field == "*" || field == "expand" || field == "expand.*" || field == "expand."+relation ||
+				strings.HasPrefix(field, "expand."+relation+".") {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, relation := range expand {
+		relation = strings.TrimSpace(strings.SplitN(relation, ".", 2)[0])
+		if relation == "" || covered(relation) {
+			continue
+		}
+		utils.PrintDebug(fmt.Sprintf("Adding 'expand.%s' to --fields so the expanded relation is returned", relation))
+		result = append(result, "expand."+relation)
+	}
+	return result
+}
+
+// withFilterValues ANDs the exact-match conditions built from --filter-value
+// field=value pairs onto filter, quoting each value safely.
+func withFilterValues(filter string, pairs []string) (string, error) {
+	if len(pairs) == 0 {
+		return filter, nil
+	}
+	values, err := utils.FilterFromValues(pairs)
+	if err != nil {
+		return "", err
+	}
+	return utils.CombineFilters(filter, values), nil
+}
+
+// withWhereConditions ANDs the filter built from --where conditions onto filter.
+func withWhereConditions(filter string, conditions []string) (string, error) {
+	if len(conditions) == 0 {
+		return filter, nil
+	}
+	where, err := utils.FilterFromConditions(conditions)
+	if err != nil {
+		return "", fmt.Errorf("invalid --where: %w", err)
+	}
+	return utils.CombineFilters(filter, where), nil
+}
+
+// parseSetFlags builds record data from --set and --set-string, given as
+// field=value. --set values that parse as JSON keep their type (42, true, null,
+// [1,2], {"a":1}, "quoted"); anything else is a string. --set-string values are
+// always strings. A field may only be given once.
+func parseSetFlags(sets, setStrings []string) (map[string]interface{}, error) {
+	data := make(map[string]interface{}, len(sets)+len(setStrings))
+	add := func(flag, assignment string, infer bool) error {
+		field, raw, found := strings.Cut(assignment, "=")
+		field = strings.TrimSpace(field)
+		if !found || field == "" {
+			return fmt.Errorf("invalid --%s %q: expected field=value", flag, assignment)
+		}
+		if _, exists := data[field]; exists {
+			return fmt.Errorf("field '%s' is set more than once", field)
+		}
+		if infer {
+			data[field] = inferSetValue(raw)
+		} else {
+			data[field] = raw
+		}
+		return nil
+	}
+
+	for _, assignment := range sets {
+		if err := add("set", assignment, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, assignment := range setStrings {
+		if err := add("set-string", assignment, false); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inferSetValue decodes raw as JSON when it is valid JSON, and otherwise keeps it
+// as a string. JSON's rules keep values like "007" or "1.0.2" as strings.
+func inferSetValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return value
+}
+
+// mergeSetValues applies --set values on top of data.
+func mergeSetValues(data, sets map[string]interface{}) {
+	for field, value := range sets {
+		data[field] = value
+	}
 }
 
 // parseJSONInput parses JSON input from a file, string argument, or stdin.
-// Precedence: file > argument > stdin
+// A file and an argument are mutually exclusive; stdin is read only when neither is given.
 func parseJSONInput(jsonStr, filePath string) (map[string]interface{}, error) {
+	jsonData, err := readJSONInput(jsonStr, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return validateAndParseJSON(string(jsonData))
+}
+
+// readJSONInput returns the raw JSON from a file, string argument, or stdin, with
+// the same precedence rules as parseJSONInput.
+func readJSONInput(jsonStr, filePath string) ([]byte, error) {
 	var jsonData []byte
 	var err error
 
+	if filePath != "" && jsonStr != "" {
+		return nil, fmt.Errorf("provide data via either a JSON argument or --file, not both")
+	}
+
 	if filePath != "" {
 		jsonData, err = os.ReadFile(filePath)
 		if err != nil {
@@ -131,13 +423,10 @@ func parseJSONInput(jsonStr, filePath string) (map[string]interface{}, error) {
 		}
 	} else if jsonStr != "" {
 		jsonData = []byte(jsonStr)
-	} else {
-		stat, _ := os.Stdin.Stat()
-		if (stat.Mode() & os.ModeCharDevice) == 0 {
-			jsonData, err = io.ReadAll(os.Stdin)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read from stdin: %w", err)
-			}
+	} else if stdinIsPiped() {
+		jsonData, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from stdin: %w", err)
 		}
 	}
 
@@ -145,7 +434,46 @@ func parseJSONInput(jsonStr, filePath string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("JSON data is required either from an argument, the --file flag, or piped from stdin")
 	}
 
-	return validateAndParseJSON(string(jsonData))
+	return jsonData, nil
+}
+
+// parseJSONRecords parses input holding either a single JSON object or an array of
+// objects. isArray reports which form was given, so callers can tell a one-element
+// array from a plain object.
+func parseJSONRecords(jsonData []byte) (records []map[string]interface{}, isArray bool, err error) {
+	trimmed := bytes.TrimSpace(jsonData)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		record, err := validateAndParseJSON(string(jsonData))
+		if err != nil {
+			return nil, false, err
+		}
+		return []map[string]interface{}{record}, false, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(trimmed, &items); err != nil {
+		return nil, true, fmt.Errorf("invalid JSON format: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, true, fmt.Errorf("JSON array is empty")
+	}
+
+	records = make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		if err := json.Unmarshal(item, &records[i]); err != nil || records[i] == nil {
+			return nil, true, fmt.Errorf("element %d is not a JSON object", i)
+		}
+	}
+	return records, true, nil
+}
+
+// stdinIsPiped reports whether stdin is a pipe or file rather than a terminal.
+func stdinIsPiped() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return (stat.Mode() & os.ModeCharDevice) == 0
 }
 
 // validateAndParseJSON validates JSON format and parses to map
@@ -0,0 +1,435 @@
+package collections
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+// TestParseJSONInputConflict checks that create and update, which both read their
+// data through parseJSONInput, reject a JSON argument combined with --file instead
+// of silently preferring the file.
+func TestParseJSONInputConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"title":"from file"}`), 0o600))
+
+	_, err := parseJSONInput(`{"title":"from arg"}`, path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not both")
+}
+
+// TestParseJSONInputSources checks that either source on its own is accepted.
+func TestParseJSONInputSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"title":"from file"}`), 0o600))
+
+	data, err := parseJSONInput("", path)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", data["title"])
+
+	data, err = parseJSONInput(`{"title":"from arg"}`, "")
+	require.NoError(t, err)
+	assert.Equal(t, "from arg", data["title"])
+}
+
+// TestParseJSONRecords checks object vs. array detection for create input.
+func TestParseJSONRecords(t *testing.T) {
+	records, isArray, err := parseJSONRecords([]byte(`{"title":"one"}`))
+	require.NoError(t, err)
+	assert.False(t, isArray)
+	require.Len(t, records, 1)
+	assert.Equal(t, "one", records[0]["title"])
+
+	records, isArray, err = parseJSONRecords([]byte("\n  [{\"title\":\"a\"},{\"title\":\"b\"}]"))
+	require.NoError(t, err)
+	assert.True(t, isArray)
+	require.Len(t, records, 2)
+	assert.Equal(t, "b", records[1]["title"])
+
+	_, _, err = parseJSONRecords([]byte(`[]`))
+	assert.Error(t, err)
+
+	_, _, err = parseJSONRecords([]byte(`[{"title":"a"}, 3]`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "element 1")
+}
+
+// TestWithExpandFields checks that expanded relations survive a --fields projection.
+func TestWithExpandFields(t *testing.T) {
+	tests := []struct {
+		fields, expand, want []string
+	}{
+		{nil, []string{"author"}, nil},
+		{[]string{"title"}, nil, []string{"title"}},
+		{[]string{"title"}, []string{"author"}, []string{"title", "expand.author"}},
+		{[]string{"title"}, []string{"author.profile", "author", "tags"}, []string{"title", "expand.author", "expand.tags"}},
+		{[]string{"title", "expand.author.name"}, []string{"author"}, []string{"title", "expand.author.name"}},
+		{[]string{"*"}, []string{"author"}, []string{"*"}},
+		{[]string{"id", "expand.*"}, []string{"author"}, []string{"id", "expand.*"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, withExpandFields(tt.fields, tt.expand), "fields=%v expand=%v", tt.fields, tt.expand)
+	}
+}
+
+// TestMatchCollectionName checks singular/plural resolution used by --fuzzy-collection.
+func TestMatchCollectionName(t *testing.T) {
+	available := []string{"posts", "category", "boxes", "users", "user_logs"}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"posts", []string{"posts"}},
+		{"post", []string{"posts"}},
+		{"categories", []string{"category"}},
+		{"box", []string{"boxes"}},
+		{"user", []string{"users"}},
+		{"comments", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchCollectionName(tt.name, available))
+		})
+	}
+}
+
+// TestMatchCollectionNamePrefersExact checks that an exact match wins even when a
+// singular/plural variant also exists.
+func TestMatchCollectionNamePrefersExact(t *testing.T) {
+	assert.Equal(t, []string{"box"}, matchCollectionName("box", []string{"box", "boxes"}))
+	assert.Equal(t, []string{"boxes"}, matchCollectionName("boxes", []string{"box", "boxes"}))
+}
+
+func TestCheckDeleteLimit(t *testing.T) {
+	assert.NoError(t, checkDeleteLimit(100, defaultDeleteLimit))
+	assert.Error(t, checkDeleteLimit(101, defaultDeleteLimit))
+	assert.NoError(t, checkDeleteLimit(1000000, 0), "0 means no limit")
+}
+
+func TestShapeListResult(t *testing.T) {
+	defer func() { withMetaFlag, unwrapFlag = false, false }()
+	result := &pocketbase.RecordsList{Page: 2, PerPage: 1, TotalItems: 3, TotalPages: 3, Items: []map[string]interface{}{{"id": "b"}}}
+
+	assert.Same(t, result, shapeListResult(result), "default keeps the PocketBase envelope")
+
+	unwrapFlag = true
+	assert.Equal(t, result.Items, shapeListResult(result))
+	assert.Equal(t, []map[string]interface{}{}, shapeListResult(&pocketbase.RecordsList{}), "empty results unwrap to [] rather than null")
+
+	unwrapFlag, withMetaFlag = false, true
+	assert.Equal(t, listWithMeta{
+		Data: result.Items,
+		Meta: listMeta{Page: 2, PerPage: 1, TotalItems: 3, TotalPages: 3},
+	}, shapeListResult(result))
+}
+
+func TestParseSetFlags(t *testing.T) {
+	data, err := parseSetFlags(
+		[]string{"views=42", "published=true", "cover=null", "tags=[\"a\",\"b\"]", "title=Hello world", "sku=007", "quoted=\"42\"", "expr=a=b"},
+		[]string{"code=42"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), data["views"])
+	assert.Equal(t, true, data["published"])
+	assert.Contains(t, data, "cover")
+	assert.Nil(t, data["cover"])
+	assert.Equal(t, []interface{}{"a", "b"}, data["tags"])
+	assert.Equal(t, "Hello world", data["title"])
+	assert.Equal(t, "007", data["sku"])
+	assert.Equal(t, "42", data["quoted"])
+	assert.Equal(t, "a=b", data["expr"])
+	assert.Equal(t, "42", data["code"])
+
+	_, err = parseSetFlags([]string{"novalue"}, nil)
+	assert.Error(t, err)
+	_, err = parseSetFlags([]string{"=1"}, nil)
+	assert.Error(t, err)
+	_, err = parseSetFlags([]string{"a=1"}, []string{"a=2"})
+	assert.Error(t, err)
+}
+
+func TestMinimalProjection(t *testing.T) {
+	schema := &pocketbase.Collection{Fields: []pocketbase.Field{
+		{Name: "id", Presentable: true},
+		{Name: "title", Presentable: true},
+		{Name: "body"},
+		{Name: "status", Presentable: true},
+	}}
+	assert.Equal(t, []string{"id", "title", "status"}, minimalProjection(schema))
+
+	// Without presentable fields, fall back to the display-name fields in the schema.
+	schema = &pocketbase.Collection{Fields: []pocketbase.Field{{Name: "id"}, {Name: "email"}, {Name: "bio"}, {Name: "name"}}}
+	assert.Equal(t, []string{"id", "email", "name"}, minimalProjection(schema))
+
+	fields, err := resolveProjectionPreset(nil, "posts", "full")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	fields, err = resolveProjectionPreset(nil, "posts", "Display")
+	require.NoError(t, err)
+	assert.Equal(t, "id", fields[0])
+	assert.Contains(t, fields, "title")
+
+	_, err = resolveProjectionPreset(nil, "posts", "tiny")
+	assert.Error(t, err)
+}
+
+func TestSeekFilter(t *testing.T) {
+	assert.Equal(t, `id > "abc123"`, seekFilter("", "abc123"))
+	assert.Equal(t, `id > "abc123"`, seekFilter("  ", "abc123"))
+	assert.Equal(t, `(status = "paid" || total > 10) && id > "abc123"`, seekFilter(`status = "paid" || total > 10`, "abc123"))
+}
+
+// TestNextSeekID checks that the seek hint is only given while pages remain,
+// including for a later --page of an id-sorted list.
+func TestNextSeekID(t *testing.T) {
+	items := []map[string]interface{}{{"id": "a"}, {"id": "b"}}
+	assert.Equal(t, "b", nextSeekID(&pocketbase.RecordsList{Page: 1, TotalPages: 2, TotalItems: 4, Items: items}))
+	assert.Empty(t, nextSeekID(&pocketbase.RecordsList{Page: 2, TotalPages: 2, TotalItems: 4, Items: items}))
+	assert.Empty(t, nextSeekID(&pocketbase.RecordsList{Page: 1, TotalPages: 1, TotalItems: 2, Items: items}))
+	assert.Empty(t, nextSeekID(&pocketbase.RecordsList{Page: 1, TotalPages: 0}))
+}
+
+func TestGroupCounts(t *testing.T) {
+	groups := &groupCounts{Counts: make(map[string]int)}
+	for _, record := range []map[string]interface{}{
+		{"status": "paid"}, {"status": "pending"}, {"status": "paid"},
+		{"status": ""}, {"status": "refunded"}, {"status": "pending"}, {"status": "paid"},
+	} {
+		groups.add(record, "status", 3)
+	}
+
+	assert.True(t, groups.Found)
+	assert.Equal(t, map[string]int{"paid": 3, "pending": 2, "": 1}, groups.Counts)
+	assert.Equal(t, 1, groups.Other, "values past the cap are only counted in Other")
+	assert.Equal(t, []string{"paid", "pending", ""}, groups.sorted())
+
+	missing := &groupCounts{Counts: make(map[string]int)}
+	missing.add(map[string]interface{}{"id": "a"}, "status", 3)
+	assert.False(t, missing.Found)
+
+	assert.Equal(t, "", groupKey(nil))
+	assert.Equal(t, "2.5", groupKey(2.5))
+	assert.Equal(t, "true", groupKey(true))
+	assert.Equal(t, `["a","b"]`, groupKey([]interface{}{"a", "b"}))
+}
+
+func TestRecordSchemaMeta(t *testing.T) {
+	schema := &pocketbase.Collection{
+		Name: "posts",
+		Type: "base",
+		Fields: []pocketbase.Field{
+			{Name: "id", Type: "text", Required: true},
+			{Name: "title", Type: "text", Required: true},
+			{Name: "views", Type: "number"},
+			{Name: "secret", Type: "text"},
+		},
+	}
+	record := map[string]interface{}{"id": "a1", "title": "Hi", "views": 3.0}
+
+	assert.Equal(t, map[string]interface{}{
+		"collection": "posts",
+		"type":       "base",
+		"fields": map[string]interface{}{
+			"id":    map[string]interface{}{"type": "text", "required": true},
+			"title": map[string]interface{}{"type": "text", "required": true},
+			"views": map[string]interface{}{"type": "number", "required": false},
+		},
+	}, recordSchemaMeta(schema, record))
+}
+
+func TestWriteIDsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "ids.txt")
+	records := []pocketbase.Record{{"id": "a1", "title": "x"}, {"id": "b2"}}
+
+	require.NoError(t, writeIDsFile(path, records))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "a1\nb2\n", string(data))
+
+	assert.Error(t, writeIDsFile(path, []pocketbase.Record{{"title": "no id"}}))
+}
+
+func TestFileTargets(t *testing.T) {
+	dir := t.TempDir()
+
+	targets, err := fileTargets("", []string{"a.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.png"}, targets)
+
+	targets, err = fileTargets(dir, []string{"a.png", "b.pdf"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.png"), filepath.Join(dir, "b.pdf")}, targets)
+
+	targets, err = fileTargets(filepath.Join(dir, "me.jpg"), []string{"a.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "me.jpg")}, targets)
+
+	_, err = fileTargets(filepath.Join(dir, "me.jpg"), []string{"a.png", "b.pdf"})
+	assert.Error(t, err)
+
+	names, err := recordFileNames(map[string]interface{}{"docs": []interface{}{"a.pdf", "b.pdf"}, "cover": ""}, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.pdf", "b.pdf"}, names)
+	_, err = recordFileNames(map[string]interface{}{"cover": ""}, "cover")
+	assert.ErrorContains(t, err, "has no file")
+}
+
+func TestServerCollections(t *testing.T) {
+	ctx := &config.Context{Name: "dev"}
+	ctx.PocketBase.CollectionDefaults = map[string]config.CollectionDefaults{"posts": {Sort: "-created"}}
+
+	rows := serverCollections([]pocketbase.Collection{
+		{Name: "_superusers", Type: "auth", System: true},
+		{Name: "posts", Type: "base"},
+	}, ctx)
+	assert.Equal(t, []serverCollection{
+		{Name: "_superusers", Type: "auth", System: true},
+		{Name: "posts", Type: "base", Configured: true},
+	}, rows)
+}
+
+// pipeStdin replaces os.Stdin with a pipe holding input for the rest of the test.
+func pipeStdin(t *testing.T, input string) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString(input)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	old := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = old
+		r.Close()
+	})
+}
+
+// TestBuildUpdateDataIgnoresPipedIDs checks that --set and --unset supply the
+// data on their own, so an ID piped in by xargs or a loop isn't parsed as JSON.
+func TestBuildUpdateDataIgnoresPipedIDs(t *testing.T) {
+	pipeStdin(t, "abc123def456ghi\n")
+	data, err := buildUpdateData("", "", map[string]interface{}{"title": "x"}, []string{"subtitle"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "x", "subtitle": nil}, data)
+
+	pipeStdin(t, `{"title":"from stdin"}`)
+	data, err = buildUpdateData("", "", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "from stdin", data["title"])
+}
+
+func TestCreateHasJSONInput(t *testing.T) {
+	pipeStdin(t, "line from a loop\n")
+	assert.False(t, createHasJSONInput("", "", map[string]interface{}{"title": "x"}))
+	assert.True(t, createHasJSONInput("", "", nil))
+	assert.True(t, createHasJSONInput(`{"a":1}`, "", map[string]interface{}{"title": "x"}))
+}
+
+// captureStderr returns what fn writes to stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	old := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = old
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+// TestCreateRecordsUpsertSummary checks that a batch upsert counts created and
+// updated records separately in both the text and the JSON summary.
+func TestCreateRecordsUpsertSummary(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			// Only slug "b" already exists.
+			if r.URL.Query().Get("filter") == "slug='b'" {
+				w.Write([]byte(`{"page":1,"perPage":1,"totalItems":1,"totalPages":1,"items":[{"id":"existingrecord1","slug":"b"}]}`))
+				return
+			}
+			w.Write([]byte(`{"page":1,"perPage":1,"totalItems":0,"totalPages":0,"items":[]}`))
+		default:
+			var data map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&data))
+			data["id"] = "record" + data["slug"].(string)
+			json.NewEncoder(w).Encode(data)
+		}
+	}))
+	defer srv.Close()
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	oldUpsert, oldQuiet, oldOutput := createUpsertKeyFlag, createQuietFlag, outputFlag
+	defer func() { createUpsertKeyFlag, createQuietFlag, outputFlag = oldUpsert, oldQuiet, oldOutput }()
+	utils.SetDataOutput(io.Discard)
+	defer utils.SetDataOutput(nil)
+	createUpsertKeyFlag, createQuietFlag = "slug", false
+
+	items := func() []map[string]interface{} {
+		return []map[string]interface{}{{"slug": "a"}, {"slug": "b"}, {"slug": "c"}}
+	}
+
+	outputFlag = config.OutputFormatTable
+	stderr := captureStderr(t, func() {
+		require.NoError(t, createRecords(client, "posts", items(), ""))
+	})
+	assert.Contains(t, stderr, "3 of 3 record(s) succeeded in 'posts' (2 created, 1 updated)")
+
+	outputFlag = config.OutputFormatJSON
+	stderr = captureStderr(t, func() {
+		require.NoError(t, createRecords(client, "posts", items(), ""))
+	})
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	var summary createSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &summary))
+	assert.Equal(t, createSummary{Collection: "posts", Total: 3, Succeeded: 3, Created: 2, Updated: 1}, summary)
+}
+
+// TestDisplayGetTableWritesToWriter checks that the table view of a record,
+// including expanded relations, goes to the given writer so --output-file
+// captures it, and nothing leaks to stdout.
+func TestDisplayGetTableWritesToWriter(t *testing.T) {
+	record := map[string]interface{}{
+		"id":      "abc123",
+		"title":   "Hello",
+		"created": "2024-01-01 00:00:00.000Z",
+		"expand": map[string]interface{}{
+			"author": map[string]interface{}{"id": "user1", "name": "Ann"},
+		},
+	}
+
+	r, pw, err := os.Pipe()
+	require.NoError(t, err)
+	old := os.Stdout
+	os.Stdout = pw
+	var buf strings.Builder
+	err = displayGetTable(&buf, record, "posts", "abc123")
+	os.Stdout = old
+	require.NoError(t, pw.Close())
+	require.NoError(t, err)
+	stdout, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Empty(t, string(stdout))
+	assert.Contains(t, buf.String(), "Posts Record: abc123")
+	assert.Contains(t, buf.String(), "Hello")
+	assert.Contains(t, buf.String(), "Ann")
+}
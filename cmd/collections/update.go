@@ -11,7 +11,15 @@ import (
 	"pb-cli/internal/utils"
 )
 
-var updateFileFlag string
+var (
+	updateFileFlag       string
+	updateUnsetFlag      []string
+	updateQuietFlag      bool
+	updateAllowLargeFlag bool
+	updateSetFlag        []string
+	updateSetStringFlag  []string
+	updateExpandFlag     []string
+)
 
 var updateCmd = &cobra.Command{
 	Use:   "update <collection> <id> [json_data]",
@@ -21,11 +29,30 @@ var updateCmd = &cobra.Command{
 Data can be provided as:
   1. A JSON string argument
   2. A file via --file flag
-  3. Piped from stdin
+  3. Piped from stdin (only read when no --set or --unset is given)
+
+Use --set field=value (repeatable) to change fields without writing JSON. Values
+are typed like JSON: numbers, true/false, and null keep their type, and anything
+that isn't valid JSON is a string. --set-string field=value always sends a
+string. With JSON data as well, --set values are applied on top of it.
+
+Use --unset to clear fields by sending them as null; JSON data is optional when
+--unset is given. PocketBase stores null as the field type's zero value:
+  text, email, url, editor, date  ""  (empty)
+  number                          0
+  bool                            false
+  select, relation, file          "" for single, [] for multiple
+  json                            null
+
+With --expand, the printed record includes the named relations expanded.
 
 Examples:
   pb collections update posts post_123 '{"published":true}'
   pb collections update posts post_123 --file updates.json
+  pb collections update posts post_123 --unset subtitle,cover
+  pb collections update posts post_123 --set published=true --set views=42
+  pb collections update posts post_123 '{"published":true}' -o json --quiet
+  pb collections update posts post_123 --set author=user_456 --expand author
   pb c update posts post_123 '{"title":"Updated"}'`,
 	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,20 +72,36 @@ Examples:
 			return fmt.Errorf("invalid record ID: %w", err)
 		}
 
-		data, err := parseJSONInput(jsonData, updateFileFlag)
+		sets, err := parseSetFlags(updateSetFlag, updateSetStringFlag)
 		if err != nil {
-			return fmt.Errorf("invalid JSON input: %w", err)
+			return err
+		}
+		if updateFileFlag != "" {
+			if err := utils.ValidateFileExists(updateFileFlag); err != nil {
+				return fmt.Errorf("invalid --file: %w", err)
+			}
+		}
+
+		data, err := buildUpdateData(jsonData, updateFileFlag, sets, updateUnsetFlag)
+		if err != nil {
+			return err
 		}
 
 		if err := validateUpdateData(data, collection); err != nil {
 			return fmt.Errorf("invalid update data: %w", err)
 		}
+		if err := checkPayloadSize(data, updateAllowLargeFlag); err != nil {
+			return err
+		}
 
 		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
 
 		utils.PrintDebug(fmt.Sprintf("Updating record '%s' in collection '%s' with data: %+v", recordID, collection, data))
 
-		record, err := client.UpdateRecord(collection, recordID, data)
+		record, err := client.UpdateRecord(collection, recordID, data, updateExpandFlag)
 		if err != nil {
 			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
 				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
@@ -73,24 +116,26 @@ Examples:
 			return fmt.Errorf("failed to update record: %w", err)
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Fprintf(os.Stderr, "%s Record updated successfully!\n", green("✓"))
+		if !updateQuietFlag {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Fprintf(os.Stderr, "%s Record updated successfully!\n", green("✓"))
 
-		fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
-		fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
+			fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
+			fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
 
-		if name := getRecordDisplayName(record); name != "" {
-			fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
-		}
+			if name := pocketbase.Record(record).GetDisplayName(); name != "" {
+				fmt.Fprintf(os.Stderr, "  Display: %s\n", name)
+			}
 
-		fieldCount := len(data)
-		if fieldCount > 0 {
-			fmt.Fprintf(os.Stderr, "  Updated %d field(s)\n", fieldCount)
+			fieldCount := len(data)
+			if fieldCount > 0 {
+				fmt.Fprintf(os.Stderr, "  Updated %d field(s)\n", fieldCount)
+			}
+
+			fmt.Fprintf(os.Stderr, "\nUpdated Record:\n")
 		}
 
 		outputFormat := getOutputFormat()
-
-		fmt.Fprintf(os.Stderr, "\nUpdated Record:\n")
 		switch outputFormat {
 		case config.OutputFormatJSON:
 			return utils.OutputData(record, config.OutputFormatJSON)
@@ -98,12 +143,44 @@ Examples:
 			return utils.OutputData(record, config.OutputFormatYAML)
 		case config.OutputFormatTable:
 			return utils.OutputData(record, config.OutputFormatTable)
+		case config.OutputFormatID:
+			return utils.OutputData(record, config.OutputFormatID)
 		default:
 			return fmt.Errorf("unsupported output format: %s", outputFormat)
 		}
 	},
 }
 
+// buildUpdateData assembles the update body from JSON input, --set values, and
+// --unset fields. JSON comes from the argument, --file, or stdin, but stdin is
+// only read when no --set/--unset gives the data: piped stdin is often the IDs
+// feeding an xargs or while-read loop, not a JSON body.
+func buildUpdateData(jsonData, filePath string, sets map[string]interface{}, unset []string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if jsonData != "" || filePath != "" || (len(sets) == 0 && len(unset) == 0) {
+		parsed, err := parseJSONInput(jsonData, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON input: %w", err)
+		}
+		data = parsed
+	}
+	mergeSetValues(data, sets)
+
+	for _, field := range unset {
+		if _, exists := data[field]; exists {
+			return nil, fmt.Errorf("field '%s' is both set in the data and passed to --unset", field)
+		}
+		data[field] = nil
+	}
+	return data, nil
+}
+
 func init() {
 	updateCmd.Flags().StringVar(&updateFileFlag, "file", "", "Path to JSON file containing record data")
+	updateCmd.Flags().BoolVarP(&updateQuietFlag, "quiet", "q", false, "Suppress the success summary; print only the record")
+	updateCmd.Flags().StringArrayVar(&updateSetFlag, "set", nil, "Set a field, typed like JSON (field=value; repeatable)")
+	updateCmd.Flags().StringArrayVar(&updateSetStringFlag, "set-string", nil, "Set a field to a string value (field=value; repeatable)")
+	updateCmd.Flags().BoolVar(&updateAllowLargeFlag, "allow-large", false, "Send record data over the large_payload_limit_bytes limit (default 10 MB) instead of refusing it")
+	updateCmd.Flags().StringSliceVar(&updateExpandFlag, "expand", nil, "Relations to expand in the returned record (comma-separated)")
+	updateCmd.Flags().StringSliceVar(&updateUnsetFlag, "unset", nil, "Fields to clear by sending null (comma-separated)")
 }
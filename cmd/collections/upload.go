@@ -0,0 +1,171 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	uploadFileFlag      []string
+	uploadDataFlag      string
+	uploadSetFlag       []string
+	uploadSetStringFlag []string
+	uploadQuietFlag     bool
+)
+
+// uploadFieldPattern matches a file field name, optionally suffixed with "+"
+// to append to a multiple-file field.
+var uploadFieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\+?$`)
+
+var uploadCmd = &cobra.Command{
+	Use:   "upload <collection> <id> [field filepath]",
+	Short: "Upload files to a record's file fields",
+	Long: `Upload local files to the file fields of an existing record.
+
+File fields can't be set with JSON; PocketBase needs a multipart request. Give a
+single field and file as arguments, or repeat --file field=path for several. A
+field name ending in "+" appends to a multiple-file field instead of replacing
+the files already there.
+
+Other fields can be changed in the same request with --data (a JSON object) or
+--set/--set-string, which work as they do for update.
+
+Examples:
+  pb collections upload posts post_123 cover ./cover.png
+  pb collections upload posts post_123 --file cover=./cover.png --file attachments+=./spec.pdf
+  pb collections upload posts post_123 cover ./cover.png --set published=true
+  pb collections upload users user_123 avatar ./me.jpg -o json --quiet`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 && len(args) != 4 {
+			return fmt.Errorf("accepts <collection> <id> and optionally <field> <filepath>, received %d arg(s)", len(args))
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection := args[0]
+		recordID := args[1]
+
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		if err := validateRecordID(recordID); err != nil {
+			return fmt.Errorf("invalid record ID: %w", err)
+		}
+
+		assignments := uploadFileFlag
+		if len(args) == 4 {
+			assignments = append([]string{args[2] + "=" + args[3]}, assignments...)
+		}
+		files, err := parseFileUploads(assignments)
+		if err != nil {
+			return err
+		}
+
+		data := make(map[string]interface{})
+		if uploadDataFlag != "" {
+			data, err = parseJSONInput(uploadDataFlag, "")
+			if err != nil {
+				return fmt.Errorf("invalid --data: %w", err)
+			}
+		}
+		sets, err := parseSetFlags(uploadSetFlag, uploadSetStringFlag)
+		if err != nil {
+			return err
+		}
+		mergeSetValues(data, sets)
+		for _, upload := range files {
+			if _, exists := data[strings.TrimSuffix(upload.Field, "+")]; exists {
+				return fmt.Errorf("field '%s' is both uploaded and set in the data", upload.Field)
+			}
+		}
+
+		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		utils.PrintDebug(fmt.Sprintf("Uploading %d file(s) to record '%s' in collection '%s'", len(files), recordID, collection))
+
+		record, err := client.UploadRecordFiles(collection, recordID, files, data)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				if additionalSuggestion := provideSuggestions(collection, "update", err); additionalSuggestion != "" {
+					fmt.Fprintf(os.Stderr, "Additional tip: %s\n", additionalSuggestion)
+				}
+				return fmt.Errorf("failed to upload files")
+			}
+			return fmt.Errorf("failed to upload files: %w", err)
+		}
+
+		if !uploadQuietFlag {
+			green := color.New(color.FgGreen).SprintFunc()
+			fmt.Fprintf(os.Stderr, "%s Uploaded %d file(s)!\n", green("✓"), len(files))
+
+			fmt.Fprintf(os.Stderr, "  Record ID: %s\n", recordID)
+			fmt.Fprintf(os.Stderr, "  Collection: %s\n", collection)
+			for _, upload := range files {
+				fmt.Fprintf(os.Stderr, "  %s <- %s\n", upload.Field, upload.Path)
+			}
+			if len(data) > 0 {
+				fmt.Fprintf(os.Stderr, "  Updated %d other field(s)\n", len(data))
+			}
+
+			fmt.Fprintf(os.Stderr, "\nUpdated Record:\n")
+		}
+
+		outputFormat := getOutputFormat()
+		switch outputFormat {
+		case config.OutputFormatJSON, config.OutputFormatYAML, config.OutputFormatTable, config.OutputFormatID:
+			return utils.OutputData(record, outputFormat)
+		default:
+			return fmt.Errorf("unsupported output format: %s", outputFormat)
+		}
+	},
+}
+
+// parseFileUploads turns field=path assignments into uploads, checking that
+// each field name is well formed and each file can be read.
+func parseFileUploads(assignments []string) ([]pocketbase.FileUpload, error) {
+	if len(assignments) == 0 {
+		return nil, fmt.Errorf("no files given: pass <field> <filepath> or --file field=path")
+	}
+
+	files := make([]pocketbase.FileUpload, 0, len(assignments))
+	for _, assignment := range assignments {
+		field, path, found := strings.Cut(assignment, "=")
+		field = strings.TrimSpace(field)
+		if !found || field == "" || path == "" {
+			return nil, fmt.Errorf("invalid --file %q: expected field=path", assignment)
+		}
+		if !uploadFieldPattern.MatchString(field) {
+			return nil, fmt.Errorf("invalid file field name %q", field)
+		}
+		if err := utils.ValidateFileExists(path); err != nil {
+			return nil, fmt.Errorf("invalid file for '%s': %w", field, err)
+		}
+		files = append(files, pocketbase.FileUpload{Field: field, Path: path})
+	}
+	return files, nil
+}
+
+func init() {
+	uploadCmd.Flags().StringArrayVar(&uploadFileFlag, "file", nil, "File to upload as field=path (repeatable; field+ appends to a multiple-file field)")
+	uploadCmd.Flags().StringVar(&uploadDataFlag, "data", "", "JSON object of other fields to update in the same request")
+	uploadCmd.Flags().StringArrayVar(&uploadSetFlag, "set", nil, "Set a field, typed like JSON (field=value; repeatable)")
+	uploadCmd.Flags().StringArrayVar(&uploadSetStringFlag, "set-string", nil, "Set a field to a string value (field=value; repeatable)")
+	uploadCmd.Flags().BoolVarP(&uploadQuietFlag, "quiet", "q", false, "Suppress the success summary; print only the record")
+}
@@ -0,0 +1,92 @@
+package collections
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	validateFileFlag   string
+	validateUpdateFlag bool
+)
+
+var validateDataCmd = &cobra.Command{
+	Use:   "validate-data <collection> [json_data]",
+	Short: "Check a record payload against the collection schema",
+	Long: `Check a create or update payload against the collection schema without sending it.
+
+The schema is fetched once and the payload is checked for unknown fields, fields
+managed by PocketBase, missing required fields, and values whose type does not fit
+the field. All problems are reported together, unlike the server which stops at
+the first. Use --update to check an update payload, where required fields may be
+omitted.
+
+Reading the schema requires superuser authentication. Field options such as
+min/max length, patterns, and select values are still only checked by the server.
+
+Data can be provided as:
+  1. A JSON string argument
+  2. A file via --file flag
+  3. Piped from stdin
+
+Examples:
+  pb collections validate-data posts --file post.json
+  pb collections validate-data posts --file changes.json --update
+  cat post.json | pb collections validate-data posts`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		collection := args[0]
+		var jsonData string
+		if len(args) > 1 {
+			jsonData = args[1]
+		}
+
+		data, err := parseJSONInput(jsonData, validateFileFlag)
+		if err != nil {
+			return fmt.Errorf("invalid JSON input: %w", err)
+		}
+
+		ctx, err := validateActiveContext()
+		if err != nil {
+			return err
+		}
+
+		client := createPocketBaseClient(ctx)
+		if collection, err = resolveCollectionName(client, collection); err != nil {
+			return err
+		}
+
+		schema, err := client.GetCollectionSchema(collection)
+		if err != nil {
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				utils.PrintError(fmt.Errorf("%s", pbErr.GetFriendlyMessage()))
+				if suggestion := pbErr.GetSuggestion(); suggestion != "" {
+					fmt.Fprintf(os.Stderr, "\nSuggestion: %s\n", suggestion)
+				}
+				return fmt.Errorf("failed to get collection schema")
+			}
+			return fmt.Errorf("failed to get collection schema: %w", err)
+		}
+
+		problems := checkPayloadAgainstSchema(data, schema, validateUpdateFlag)
+		if len(problems) == 0 {
+			utils.PrintSuccess(fmt.Sprintf("Payload is valid for collection '%s'", collection))
+			return nil
+		}
+
+		fmt.Fprintf(os.Stderr, "Payload for collection '%s' has %d problem(s):\n", collection, len(problems))
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		return fmt.Errorf("payload validation failed")
+	},
+}
+
+func init() {
+	validateDataCmd.Flags().StringVar(&validateFileFlag, "file", "", "Path to JSON file containing record data")
+	validateDataCmd.Flags().BoolVar(&validateUpdateFlag, "update", false, "Check as an update payload (required fields may be omitted)")
+}
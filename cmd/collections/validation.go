@@ -1,9 +1,14 @@
 package collections
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
 	"pb-cli/internal/utils"
 )
 
@@ -108,6 +113,28 @@ func validateFieldValue(fieldName string, value interface{}) error {
 	return nil
 }
 
+// checkPayloadSize measures data as it will be sent and warns when it is large,
+// or fails when it exceeds the limit and allowLarge isn't set. The sizes come
+// from large_payload_warn_bytes and large_payload_limit_bytes.
+func checkPayloadSize(data map[string]interface{}, allowLarge bool) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode record data: %w", err)
+	}
+
+	size := int64(len(body))
+	limit := config.Global.LargePayloadLimitSize()
+	warn := config.Global.LargePayloadWarnSize()
+	switch {
+	case limit > 0 && size > limit && !allowLarge:
+		return fmt.Errorf("record data is %s, over the %s limit; pass --allow-large to send it anyway",
+			utils.FormatBytes(size), utils.FormatBytes(limit))
+	case warn > 0 && size > warn:
+		utils.PrintWarning(fmt.Sprintf("record data is %s; large payloads may be rejected by the server", utils.FormatBytes(size)))
+	}
+	return nil
+}
+
 // validateRecordID validates a record ID format
 func validateRecordID(recordID string) error {
 	if recordID == "" {
@@ -122,6 +149,25 @@ func validateRecordID(recordID string) error {
 	return nil
 }
 
+// PocketBase's default record ID format.
+const customRecordIDLength = 15
+
+// validateCustomRecordID checks a user-supplied ID for a new record against
+// PocketBase's default ID rules: exactly 15 characters of a-z and 0-9.
+func validateCustomRecordID(id string) error {
+	if len(id) != customRecordIDLength {
+		return fmt.Errorf("record ID must be exactly %d characters, got %d", customRecordIDLength, len(id))
+	}
+
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') {
+			return fmt.Errorf("record ID may only contain lowercase letters and digits, found '%c'", r)
+		}
+	}
+
+	return nil
+}
+
 // provideSuggestions provides helpful suggestions based on common errors
 func provideSuggestions(collection string, action string, err error) string {
 	errMsg := err.Error()
@@ -158,3 +204,151 @@ func provideSuggestions(collection string, action string, err error) string {
 func contains(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
+
+// checkPayloadAgainstSchema checks data against a collection's schema and returns
+// every problem found rather than stopping at the first: unknown fields, fields
+// managed by PocketBase, missing required fields (create only), and values whose
+// JSON type cannot be stored in the field.
+func checkPayloadAgainstSchema(data map[string]interface{}, schema *pocketbase.Collection, isUpdate bool) []string {
+	fields := make(map[string]pocketbase.Field, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+
+	var problems []string
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := fieldModifierTarget(key)
+
+		field, exists := fields[name]
+		if !exists {
+			if schema.Type == "auth" && name == "passwordConfirm" {
+				continue
+			}
+			problems = append(problems, fmt.Sprintf("unknown field '%s'", key))
+			continue
+		}
+
+		if field.System && field.Name == "id" {
+			problems = append(problems, "field 'id' is automatically managed; use --id with create to set a custom ID")
+			continue
+		}
+		if field.Type == "autodate" {
+			problems = append(problems, fmt.Sprintf("field '%s' is set automatically and should not be included", key))
+			continue
+		}
+
+		if expected := schemaTypeMismatch(field, data[key]); expected != "" {
+			problems = append(problems, fmt.Sprintf("field '%s' (%s) expects %s, got %s", key, field.Type, expected, jsonTypeName(data[key])))
+		}
+	}
+
+	if !isUpdate {
+		for _, field := range schema.Fields {
+			if !field.Required || field.System || field.Type == "autodate" {
+				continue
+			}
+			if value, exists := data[field.Name]; !exists || value == nil || value == "" {
+				problems = append(problems, fmt.Sprintf("required field '%s' is missing", field.Name))
+			}
+		}
+	}
+
+	return problems
+}
+
+// fieldModifierTarget returns the field a data key writes to, without the
+// PocketBase modifiers "+field" (prepend), "field+" (append or add), and
+// "field-" (remove or subtract).
+func fieldModifierTarget(key string) string {
+	name := strings.TrimPrefix(key, "+")
+	if trimmed := strings.TrimSuffix(name, "+"); trimmed != name {
+		return trimmed
+	}
+	return strings.TrimSuffix(name, "-")
+}
+
+// schemaTypeMismatch returns a description of the expected JSON type when value is
+// incompatible with field, or "" when it fits. Values the server casts are
+// accepted: numbers and booleans for text fields, numeric strings for numbers,
+// and "true"/"false" style strings or numbers for booleans. Null is always
+// accepted since it clears the field, and unfamiliar field types are left to the
+// server.
+func schemaTypeMismatch(field pocketbase.Field, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	switch field.Type {
+	case "text", "editor", "password":
+		switch value.(type) {
+		case string, float64, bool:
+		default:
+			return "a string"
+		}
+	case "email", "url", "date":
+		if _, ok := value.(string); !ok {
+			return "a string"
+		}
+	case "number":
+		switch v := value.(type) {
+		case float64:
+		case string:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil && v != "" {
+				return "a number"
+			}
+		default:
+			return "a number"
+		}
+	case "bool":
+		switch v := value.(type) {
+		case bool, float64:
+		case string:
+			if _, err := strconv.ParseBool(v); err != nil && v != "" {
+				return "a boolean"
+			}
+		default:
+			return "a boolean"
+		}
+	case "select", "relation", "file":
+		switch v := value.(type) {
+		case string:
+		case []interface{}:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return "a string or an array of strings"
+				}
+			}
+		default:
+			return "a string or an array of strings"
+		}
+	}
+
+	return ""
+}
+
+// jsonTypeName names the JSON type of a decoded value for error messages.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,118 @@
+package collections
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+)
+
+func TestValidateCustomRecordID(t *testing.T) {
+	testCases := []struct {
+		name  string
+		id    string
+		valid bool
+	}{
+		{"Valid", "abc123def456ghi", true},
+		{"Too short", "abc123", false},
+		{"Too long", "abc123def456ghij", false},
+		{"Uppercase", "ABC123def456ghi", false},
+		{"Symbol", "abc123def456gh_", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCustomRecordID(tc.id)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateCreateDataRejectsID checks that an id in the payload is still refused;
+// custom IDs must go through --id.
+func TestValidateCreateDataRejectsID(t *testing.T) {
+	err := validateCreateData(map[string]interface{}{"id": "abc123def456ghi", "title": "x"}, "posts")
+	assert.Error(t, err)
+}
+
+func TestCheckPayloadAgainstSchema(t *testing.T) {
+	schema := &pocketbase.Collection{
+		Name: "posts",
+		Type: "base",
+		Fields: []pocketbase.Field{
+			{Name: "id", Type: "text", System: true, Required: true},
+			{Name: "title", Type: "text", Required: true},
+			{Name: "views", Type: "number"},
+			{Name: "tags", Type: "select"},
+			{Name: "created", Type: "autodate"},
+		},
+	}
+
+	t.Run("Valid create", func(t *testing.T) {
+		data := map[string]interface{}{"title": "Hi", "views": float64(3), "tags": []interface{}{"a"}}
+		assert.Empty(t, checkPayloadAgainstSchema(data, schema, false))
+	})
+
+	t.Run("Reports all problems", func(t *testing.T) {
+		data := map[string]interface{}{"views": "many", "bogus": true, "created": "x", "tags": float64(1)}
+		assert.Equal(t, []string{
+			"unknown field 'bogus'",
+			"field 'created' is set automatically and should not be included",
+			"field 'tags' (select) expects a string or an array of strings, got number",
+			"field 'views' (number) expects a number, got string",
+			"required field 'title' is missing",
+		}, checkPayloadAgainstSchema(data, schema, false))
+	})
+
+	t.Run("Update skips required and accepts modifiers", func(t *testing.T) {
+		data := map[string]interface{}{"tags+": "b", "+tags": "a", "tags-": "c", "views": nil}
+		assert.Empty(t, checkPayloadAgainstSchema(data, schema, true))
+	})
+
+	t.Run("Accepts values the server casts", func(t *testing.T) {
+		schema := &pocketbase.Collection{Name: "posts", Type: "base", Fields: []pocketbase.Field{
+			{Name: "title", Type: "text"},
+			{Name: "views", Type: "number"},
+			{Name: "published", Type: "bool"},
+		}}
+		data := map[string]interface{}{"title": float64(42), "views": "12.5", "published": "true"}
+		assert.Empty(t, checkPayloadAgainstSchema(data, schema, false))
+		data = map[string]interface{}{"views": "many", "published": "maybe"}
+		assert.Equal(t, []string{
+			"field 'published' (bool) expects a boolean, got string",
+			"field 'views' (number) expects a number, got string",
+		}, checkPayloadAgainstSchema(data, schema, false))
+	})
+}
+
+func TestCheckPayloadSize(t *testing.T) {
+	assert.NoError(t, checkPayloadSize(map[string]interface{}{"title": "small"}, false))
+
+	// Strings are capped per field, so a large body comes from many of them.
+	chunk := strings.Repeat("x", 10000)
+	items := make([]interface{}, config.DefaultLargePayloadLimitBytes/len(chunk)+1)
+	for i := range items {
+		items[i] = chunk
+	}
+	large := map[string]interface{}{"items": items}
+
+	err := checkPayloadSize(large, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--allow-large")
+	assert.NoError(t, checkPayloadSize(large, true))
+
+	// The limit comes from large_payload_limit_bytes; 0 turns it off.
+	oldLimit := config.Global.LargePayloadLimitBytes
+	defer func() { config.Global.LargePayloadLimitBytes = oldLimit }()
+	limit := int64(100)
+	config.Global.LargePayloadLimitBytes = &limit
+	assert.Error(t, checkPayloadSize(map[string]interface{}{"title": strings.Repeat("x", 200)}, false))
+	limit = 0
+	assert.NoError(t, checkPayloadSize(large, false))
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	pbconfig "pb-cli/internal/config"
+	"pb-cli/internal/utils"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named flag profiles",
+	Long: `Manage named flag profiles.
+
+A profile is a set of flag values applied with --profile <name> or by setting
+PB_PROFILE. Profile values override the global config; flags given on the
+command line and per-collection context defaults still win. A profile value
+never counts as a typed flag, so it doesn't conflict with flags like --query.
+Settings for flags a command doesn't have (quiet on list, for example) are
+skipped.
+
+Examples:
+  pb config profile set scripting output=json quiet=true colors=false
+  pb config profile set scripting quiet=        # remove one setting
+  pb config profile list
+  pb --profile scripting collections get posts post_123
+  PB_PROFILE=scripting pb collections list posts`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("missing subcommand. Available: list, set")
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the configured profiles",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+		globalConfig, err := configManager.LoadGlobalConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+
+		w := utils.DataOutput()
+		names := globalConfig.ProfileNames()
+		if len(names) == 0 {
+			fmt.Fprintln(w, "No profiles configured.")
+			fmt.Fprintf(w, "\nCreate one:\n  %s\n",
+				color.New(color.FgCyan).Sprint("pb config profile set <name> output=json quiet=true"))
+			return nil
+		}
+
+		active := os.Getenv(pbconfig.ProfileEnvVar)
+		for _, name := range names {
+			marker := " "
+			if name == active {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%s %s\n", marker, name)
+			profile := globalConfig.Profiles[name]
+			for _, flag := range profile.Flags() {
+				fmt.Fprintf(w, "    %s=%s\n", flag, profile[flag])
+			}
+		}
+		if active != "" {
+			fmt.Fprintf(w, "\n* selected by %s\n", pbconfig.ProfileEnvVar)
+		}
+		return nil
+	},
+}
+
+var profileSetCmd = &cobra.Command{
+	Use:   "set <name> <flag=value>...",
+	Short: "Create or change a profile",
+	Long: `Create a profile or change its settings.
+
+Each setting is a flag name without dashes and its value. An empty value
+removes the setting; a profile left with no settings is removed.
+
+Examples:
+  pb config profile set scripting output=json quiet=true colors=false
+  pb config profile set scripting colors=`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+		name := args[0]
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("profile name cannot be empty")
+		}
+
+		known := knownFlags(cmd.Root())
+		settings := make(map[string]string, len(args)-1)
+		for _, assignment := range args[1:] {
+			flag, value, found := strings.Cut(assignment, "=")
+			flag = strings.TrimLeft(strings.TrimSpace(flag), "-")
+			if !found || flag == "" {
+				return fmt.Errorf("invalid setting %q: expected flag=value", assignment)
+			}
+			if flag == "profile" {
+				return fmt.Errorf("a profile cannot select another profile")
+			}
+			if !known[flag] {
+				return fmt.Errorf("unknown flag %q", flag)
+			}
+			settings[flag] = value
+		}
+
+		globalConfig, err := configManager.LoadGlobalConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+
+		profile := globalConfig.Profiles[name]
+		if profile == nil {
+			profile = make(pbconfig.Profile)
+		}
+		for flag, value := range settings {
+			if value == "" {
+				delete(profile, flag)
+			} else {
+				profile[flag] = value
+			}
+		}
+
+		if globalConfig.Profiles == nil {
+			globalConfig.Profiles = make(map[string]pbconfig.Profile)
+		}
+		if len(profile) == 0 {
+			delete(globalConfig.Profiles, name)
+		} else {
+			globalConfig.Profiles[name] = profile
+		}
+
+		if err := configManager.SaveGlobalConfig(globalConfig); err != nil {
+			return fmt.Errorf("failed to save global config: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		if len(profile) == 0 {
+			fmt.Printf("%s Profile '%s' removed (no settings left)\n", green("✓"), name)
+			return nil
+		}
+		fmt.Printf("%s Profile '%s' saved\n", green("✓"), name)
+		for _, flag := range profile.Flags() {
+			fmt.Printf("  %s=%s\n", flag, profile[flag])
+		}
+		return nil
+	},
+}
+
+// knownFlags returns the names of every flag defined anywhere in the command
+// tree, so profile settings with typos are caught when they are saved.
+func knownFlags(root *cobra.Command) map[string]bool {
+	known := make(map[string]bool)
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		add := func(f *pflag.Flag) { known[f.Name] = true }
+		cmd.Flags().VisitAll(add)
+		cmd.PersistentFlags().VisitAll(add)
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+	delete(known, "help")
+	return known
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileSetCmd)
+}
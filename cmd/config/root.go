@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	pbconfig "pb-cli/internal/config"
+)
+
+// ConfigCmd represents the config command
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage global pb settings",
+	Long: `Manage settings stored in the global configuration file
+(~/.config/pb/config.yaml).
+
+Examples:
+  pb config profile list
+  pb config profile set scripting output=json quiet=true colors=false`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("missing subcommand. Available: profile")
+	},
+}
+
+var configManager *pbconfig.Manager
+
+func init() {
+	ConfigCmd.AddCommand(profileCmd)
+}
+
+// SetConfigManager sets the configuration manager for the config commands
+func SetConfigManager(cm *pbconfig.Manager) {
+	configManager = cm
+}
+
+// validateConfigManager ensures the config manager is available
+func validateConfigManager() error {
+	if configManager == nil {
+		return fmt.Errorf("configuration manager not initialized")
+	}
+	return nil
+}
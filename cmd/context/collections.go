@@ -0,0 +1,258 @@
+package context
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+)
+
+var (
+	defaultFilterFlag string
+	defaultSortFlag   string
+	defaultFieldsFlag []string
+)
+
+var collectionsCmd = &cobra.Command{
+	Use:   "collections",
+	Short: "Manage per-collection settings in the active context",
+	Long: `Manage per-collection settings stored in the active context.
+
+Default list options are applied by 'pb collections list <collection>' whenever
+the matching flag is not given, so common base filters don't need repeating.
+Flags always win: pass --filter '' to list without the default filter.
+
+Use 'rename' after a collection is renamed on the server to carry its list
+defaults and saved filter presets over to the new name.
+
+Examples:
+  pb context collections set-default posts --filter 'deleted=false' --sort -created
+  pb context collections clear-default posts
+  pb context collections rename posts articles`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return fmt.Errorf("missing subcommand. Available: set-default, clear-default, rename")
+	},
+}
+
+var setDefaultCmd = &cobra.Command{
+	Use:   "set-default <collection>",
+	Short: "Set default list options for a collection",
+	Long: `Set default filter, sort, or fields for listing a collection in the active context.
+
+Only the options given are changed; pass an empty value (e.g. --sort '') to remove
+one default while keeping the others.
+
+Examples:
+  pb context collections set-default posts --filter 'deleted=false'
+  pb context collections set-default posts --sort -created --fields id,title
+  pb context collections set-default posts --sort ''`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		collection := strings.TrimSpace(args[0])
+		if collection == "" {
+			return fmt.Errorf("collection name cannot be empty")
+		}
+
+		flags := cmd.Flags()
+		if !flags.Changed("filter") && !flags.Changed("sort") && !flags.Changed("fields") {
+			return fmt.Errorf("specify at least one of --filter, --sort, or --fields")
+		}
+
+		ctx, err := configManager.GetActiveContext()
+		if err != nil {
+			return fmt.Errorf("no active context set. Use 'pb context select <name>' to set one")
+		}
+
+		defaults := ctx.PocketBase.CollectionDefaults[collection]
+		if flags.Changed("filter") {
+			defaults.Filter = defaultFilterFlag
+		}
+		if flags.Changed("sort") {
+			defaults.Sort = defaultSortFlag
+		}
+		if flags.Changed("fields") {
+			defaults.Fields = defaultFieldsFlag
+		}
+
+		if defaults.IsEmpty() {
+			delete(ctx.PocketBase.CollectionDefaults, collection)
+		} else {
+			if ctx.PocketBase.CollectionDefaults == nil {
+				ctx.PocketBase.CollectionDefaults = make(map[string]config.CollectionDefaults)
+			}
+			ctx.PocketBase.CollectionDefaults[collection] = defaults
+		}
+
+		if err := configManager.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to save context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		if defaults.IsEmpty() {
+			fmt.Printf("%s Cleared list defaults for '%s' in context '%s'\n", green("✓"), collection, ctx.Name)
+			return nil
+		}
+		fmt.Printf("%s Saved list defaults for '%s' in context '%s'\n", green("✓"), collection, ctx.Name)
+		printCollectionDefaults(os.Stdout, defaults, "  ")
+		return nil
+	},
+}
+
+var clearDefaultCmd = &cobra.Command{
+	Use:   "clear-default <collection>",
+	Short: "Remove all default list options for a collection",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		collection := strings.TrimSpace(args[0])
+
+		ctx, err := configManager.GetActiveContext()
+		if err != nil {
+			return fmt.Errorf("no active context set. Use 'pb context select <name>' to set one")
+		}
+
+		if _, exists := ctx.PocketBase.CollectionDefaults[collection]; !exists {
+			return fmt.Errorf("no list defaults set for '%s' in context '%s'", collection, ctx.Name)
+		}
+		delete(ctx.PocketBase.CollectionDefaults, collection)
+
+		if err := configManager.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to save context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Cleared list defaults for '%s' in context '%s'\n", green("✓"), collection, ctx.Name)
+		return nil
+	},
+}
+
+var renameCollectionCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Move a collection's list defaults and filter presets to a new name",
+	Long: `Rename the per-collection settings of the active context in one step: the
+list defaults and saved filter presets stored under <old> are moved to <new>.
+
+Nothing on the server changes; run this after renaming the collection in
+PocketBase. Fails if <new> already has settings, so nothing is overwritten.
+
+Examples:
+  pb context collections rename posts articles`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		oldName, newName := strings.TrimSpace(args[0]), strings.TrimSpace(args[1])
+		if err := validateCollectionName(newName); err != nil {
+			return err
+		}
+		if oldName == newName {
+			return fmt.Errorf("old and new collection names are the same")
+		}
+
+		ctx, err := configManager.GetActiveContext()
+		if err != nil {
+			return fmt.Errorf("no active context set. Use 'pb context select <name>' to set one")
+		}
+		presets, err := configManager.LoadFilterPresets(ctx.Name)
+		if err != nil {
+			return err
+		}
+
+		defaults, hasDefaults := ctx.PocketBase.CollectionDefaults[oldName]
+		hasPresets := len(presets[oldName]) > 0
+		if !hasDefaults && !hasPresets {
+			return fmt.Errorf("no settings stored for '%s' in context '%s'", oldName, ctx.Name)
+		}
+		if _, exists := ctx.PocketBase.CollectionDefaults[newName]; exists || len(presets[newName]) > 0 {
+			return fmt.Errorf("'%s' already has settings in context '%s'; clear them first", newName, ctx.Name)
+		}
+
+		if hasDefaults {
+			delete(ctx.PocketBase.CollectionDefaults, oldName)
+			ctx.PocketBase.CollectionDefaults[newName] = defaults
+			if err := configManager.SaveContext(ctx); err != nil {
+				return fmt.Errorf("failed to save context: %w", err)
+			}
+		}
+		if hasPresets {
+			presets[newName] = presets[oldName]
+			delete(presets, oldName)
+			if err := configManager.SaveFilterPresets(ctx.Name, presets); err != nil {
+				// Put the list defaults back so the two files stay consistent.
+				if hasDefaults {
+					delete(ctx.PocketBase.CollectionDefaults, newName)
+					ctx.PocketBase.CollectionDefaults[oldName] = defaults
+					if rollbackErr := configManager.SaveContext(ctx); rollbackErr != nil {
+						return fmt.Errorf("%w; list defaults were moved to '%s' but could not be moved back: %v", err, newName, rollbackErr)
+					}
+				}
+				return err
+			}
+		}
+
+		var moved []string
+		if hasDefaults {
+			moved = append(moved, "list defaults")
+		}
+		if hasPresets {
+			moved = append(moved, fmt.Sprintf("%d filter preset(s)", len(presets[newName])))
+		}
+		green := color.New(color.FgGreen).SprintFunc()
+		fmt.Printf("%s Moved %s from '%s' to '%s' in context '%s'\n",
+			green("✓"), strings.Join(moved, " and "), oldName, newName, ctx.Name)
+		return nil
+	},
+}
+
+func init() {
+	setDefaultCmd.Flags().StringVar(&defaultFilterFlag, "filter", "", "Default filter expression")
+	setDefaultCmd.Flags().StringVar(&defaultSortFlag, "sort", "", "Default sort expression")
+	setDefaultCmd.Flags().StringSliceVar(&defaultFieldsFlag, "fields", nil, "Default fields to return (comma-separated)")
+
+	collectionsCmd.AddCommand(setDefaultCmd)
+	collectionsCmd.AddCommand(clearDefaultCmd)
+	collectionsCmd.AddCommand(renameCollectionCmd)
+}
+
+// validateCollectionName checks a name against PocketBase's collection naming
+// rules: letters, digits, and underscores, not starting with a digit.
+func validateCollectionName(name string) error {
+	if name == "" {
+		return fmt.Errorf("collection name cannot be empty")
+	}
+	for i, char := range name {
+		if !((char >= 'a' && char <= 'z') ||
+			(char >= 'A' && char <= 'Z') ||
+			(char >= '0' && char <= '9' && i > 0) ||
+			char == '_') {
+			return fmt.Errorf("invalid collection name '%s': use letters, digits, and underscores, not starting with a digit", name)
+		}
+	}
+	return nil
+}
+
+// printCollectionDefaults writes the non-empty defaults to w, one per line.
+func printCollectionDefaults(w io.Writer, defaults config.CollectionDefaults, indent string) {
+	if defaults.Filter != "" {
+		fmt.Fprintf(w, "%sFilter: %s\n", indent, defaults.Filter)
+	}
+	if defaults.Sort != "" {
+		fmt.Fprintf(w, "%sSort:   %s\n", indent, defaults.Sort)
+	}
+	if len(defaults.Fields) > 0 {
+		fmt.Fprintf(w, "%sFields: %s\n", indent, strings.Join(defaults.Fields, ","))
+	}
+}
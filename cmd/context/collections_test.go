@@ -0,0 +1,17 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateCollectionName checks the names accepted by 'collections rename'.
+func TestValidateCollectionName(t *testing.T) {
+	for _, name := range []string{"posts", "user_logs", "_superusers", "Posts2"} {
+		assert.NoError(t, validateCollectionName(name), name)
+	}
+	for _, name := range []string{"", "2posts", "my-posts", "posts.old", "pöst"} {
+		assert.Error(t, validateCollectionName(name), name)
+	}
+}
@@ -1,13 +1,11 @@
 package context
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"pb-cli/internal/utils"
 )
 
 var forceDelete bool
@@ -20,8 +18,8 @@ var deleteCmd = &cobra.Command{
 This action is irreversible and will remove the entire context directory
 including the context configuration and any context-specific files.
 
-If the context being deleted is currently active, you will need to
-select a different context or create a new one.
+If the context being deleted is currently active, you must type its name to
+confirm, and afterwards select a different context or create a new one.
 
 Examples:
   pb context delete development
@@ -77,16 +75,20 @@ Examples:
 		if !forceDelete {
 			fmt.Printf("\n%s This will permanently delete the entire context directory and all its contents.\n",
 				yellow("Warning:"))
-			fmt.Print("Are you sure you want to delete this context? (y/N): ")
 
-			reader := bufio.NewReader(os.Stdin)
-			response, err := reader.ReadString('\n')
+			// Deleting the active context leaves pb without a target, so it requires
+			// typing the context name rather than a quick y/N.
+			var confirmed bool
+			if isActive {
+				confirmed, err = utils.ConfirmWord(
+					fmt.Sprintf("Type the context name '%s' to confirm: ", contextName), contextName)
+			} else {
+				confirmed, err = utils.Confirm("Are you sure you want to delete this context? (y/N): ")
+			}
 			if err != nil {
-				return fmt.Errorf("failed to read confirmation: %w", err)
+				return err
 			}
-
-			response = strings.TrimSpace(strings.ToLower(response))
-			if response != "y" && response != "yes" {
+			if !confirmed {
 				fmt.Println("Context deletion cancelled.")
 				return nil
 			}
@@ -2,12 +2,12 @@ package context
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"pb-cli/internal/config"
+	"pb-cli/internal/utils"
 )
 
 var listCmd = &cobra.Command{
@@ -25,6 +25,7 @@ Examples:
   pb context ls`,
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		w := utils.DataOutput()
 		if err := validateConfigManager(); err != nil {
 			return err
 		}
@@ -36,8 +37,8 @@ Examples:
 		}
 
 		if len(contexts) == 0 {
-			fmt.Printf("No contexts configured in %s.\n", configManager.GetConfigDir())
-			fmt.Printf("\nCreate your first context:\n  %s\n",
+			fmt.Fprintf(w, "No contexts configured in %s.\n", configManager.GetConfigDir())
+			fmt.Fprintf(w, "\nCreate your first context:\n  %s\n",
 				color.New(color.FgCyan).Sprint("pb context create <name> --url <url>"))
 			return nil
 		}
@@ -51,12 +52,19 @@ Examples:
 		// Process contexts and display
 		displayContextsTable(contexts, globalConfig.ActiveContext)
 
+		if mismatches, err := configManager.FindContextNameMismatches(); err == nil {
+			for _, mismatch := range mismatches {
+				utils.PrintWarning(fmt.Sprintf("context directory '%s' contains context '%s'. Run 'pb context repair' to fix",
+					mismatch.Dir, mismatch.Name))
+			}
+		}
+
 		// Show active context summary
 		if globalConfig.ActiveContext != "" {
-			fmt.Printf("\nActive context: %s\n",
+			fmt.Fprintf(w, "\nActive context: %s\n",
 				color.New(color.FgCyan).Sprint(globalConfig.ActiveContext))
 		} else {
-			fmt.Printf("\nNo active context set. Use %s to select one.\n",
+			fmt.Fprintf(w, "\nNo active context set. Use %s to select one.\n",
 				color.New(color.FgCyan).Sprint("pb context select <name>"))
 		}
 
@@ -77,6 +85,7 @@ type ContextDisplayInfo struct {
 
 // displayContextsTable processes contexts and displays them in a properly formatted table
 func displayContextsTable(contextNames []string, activeContext string) {
+	w := utils.DataOutput()
 	// Process all contexts first
 	var contexts []ContextDisplayInfo
 	for _, name := range contextNames {
@@ -98,7 +107,7 @@ func displayContextsTable(contextNames []string, activeContext string) {
 		})
 	}
 
-	fmt.Printf("PocketBase Contexts (stored in %s):\n", configManager.GetConfigDir())
+	fmt.Fprintf(w, "PocketBase Contexts (stored in %s):\n", configManager.GetConfigDir())
 	table.Render()
 }
 
@@ -131,7 +140,8 @@ func processContextForDisplay(contextName, activeContext string) ContextDisplayI
 
 // createContextTable creates and configures the table with proper column settings
 func createContextTable() *tablewriter.Table {
-	table := tablewriter.NewWriter(os.Stdout)
+	w := utils.DataOutput()
+	table := tablewriter.NewWriter(w)
 
 	// Set headers
 	table.SetHeader([]string{"NAME", "STATUS", "POCKETBASE URL", "AUTH COLLECTION", "LAST AUTH"})
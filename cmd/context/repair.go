@@ -0,0 +1,89 @@
+package context
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/utils"
+)
+
+var repairForceFlag bool
+
+var repairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "Fix context directories whose name differs from the name inside them",
+	Long: `Find contexts whose directory name differs from the name field in their
+context.yaml, as happens when a context directory is copied or renamed by hand,
+and offer to rename each directory to match.
+
+Such a context is listed and selected by its directory name, but saving it (for
+example after 'pb auth') writes to the directory of the name inside the file,
+which may be another context entirely.
+
+A directory is not renamed when a context with the target name already exists;
+edit the name in its context.yaml instead.
+
+Examples:
+  pb context repair
+  pb context repair --force  # Rename without asking`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateConfigManager(); err != nil {
+			return err
+		}
+
+		mismatches, err := configManager.FindContextNameMismatches()
+		if err != nil {
+			return fmt.Errorf("failed to check contexts: %w", err)
+		}
+		if len(mismatches) == 0 {
+			utils.PrintSuccess("All context directories match their context names.")
+			return nil
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		failed := 0
+		for _, mismatch := range mismatches {
+			if !repairForceFlag {
+				confirmed, err := utils.Confirm(fmt.Sprintf("Rename context directory '%s' to '%s' (the name in its context.yaml)? (y/N): ",
+					mismatch.Dir, mismatch.Name))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Fprintf(os.Stderr, "Skipped '%s'.\n", mismatch.Dir)
+					continue
+				}
+			}
+
+			if err := configManager.RepairContextName(mismatch); err != nil {
+				utils.PrintError(err)
+				failed++
+				continue
+			}
+			fmt.Printf("%s Renamed context directory '%s' to '%s'\n", green("✓"), mismatch.Dir, mismatch.Name)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to repair %d context(s)", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	repairCmd.Flags().BoolVarP(&repairForceFlag, "force", "f", false, "Rename without asking for confirmation")
+}
+
+// warnContextNameMismatch warns when a context was loaded from a directory that
+// doesn't match the name inside it, pointing at 'pb context repair'.
+func warnContextNameMismatch(dir string, ctx *config.Context) {
+	if ctx.Name == dir {
+		return
+	}
+	utils.PrintWarning(fmt.Sprintf("context directory '%s' contains context '%s'; saving it would write to '%s'. Run 'pb context repair' to fix",
+		dir, ctx.Name, ctx.Name))
+}
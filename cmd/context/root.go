@@ -48,6 +48,8 @@ func init() {
 	ContextCmd.AddCommand(selectCmd)
 	ContextCmd.AddCommand(showCmd)
 	ContextCmd.AddCommand(deleteCmd)
+	ContextCmd.AddCommand(collectionsCmd)
+	ContextCmd.AddCommand(repairCmd)
 }
 
 // SetConfigManager sets the configuration manager for the context commands
@@ -5,8 +5,12 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"pb-cli/cmd/auth"
+	"pb-cli/internal/pocketbase"
 )
 
+var selectAuthFlag bool
+
 var selectCmd = &cobra.Command{
 	Use:   "select <name>",
 	Short: "Set the active PocketBase context",
@@ -15,8 +19,13 @@ var selectCmd = &cobra.Command{
 The active context determines which PocketBase instance and collection settings
 are used for all pb commands.
 
+With --auth, if the context has no token or its token has expired, 'pb auth' runs
+right after switching, taking credentials from PB_EMAIL and PB_PASSWORD or
+prompting for them. A context with a valid token is left as is.
+
 Examples:
   pb context select production
+  pb context select staging --auth  # Switch and log in if needed
   pb context select development
   pb con sel prod  # Using partial matching`,
 	Aliases: []string{"use", "switch"},
@@ -40,6 +49,8 @@ Examples:
 			return fmt.Errorf("context '%s' not found", contextName)
 		}
 
+		warnContextNameMismatch(contextName, ctx)
+
 		// Set as active context
 		if err := configManager.SetActiveContext(contextName); err != nil {
 			return fmt.Errorf("failed to set active context: %w", err)
@@ -60,7 +71,8 @@ Examples:
 		fmt.Printf("  Auth Collection: %s\n", ctx.PocketBase.AuthCollection)
 
 		// Authentication status
-		if ctx.PocketBase.AuthToken != "" {
+		authValid := pocketbase.IsAuthValid(ctx)
+		if authValid {
 			if ctx.PocketBase.AuthExpires != nil {
 				fmt.Printf("  Authentication: %s (expires %s)\n",
 					green("Valid"),
@@ -69,8 +81,17 @@ Examples:
 				fmt.Printf("  Authentication: %s\n", green("Valid"))
 			}
 		} else {
+			status := "Required"
+			if ctx.PocketBase.AuthToken != "" {
+				status = "Expired"
+			}
 			fmt.Printf("  Authentication: %s\n",
-				color.New(color.FgYellow).Sprint("Required"))
+				color.New(color.FgYellow).Sprint(status))
+
+			if selectAuthFlag {
+				fmt.Println()
+				return auth.Authenticate()
+			}
 
 			// Suggest authentication
 			fmt.Printf("\nNext steps:\n")
@@ -81,3 +102,7 @@ Examples:
 		return nil
 	},
 }
+
+func init() {
+	selectCmd.Flags().BoolVar(&selectAuthFlag, "auth", false, "Authenticate right away if the context's token is missing or expired")
+}
@@ -1,18 +1,32 @@
 package context
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 	"pb-cli/internal/config"
 	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
 )
 
-var showOutputFormat string
+var (
+	showOutputFormat string
+	showCheckAuth    bool
+)
+
+// Results of a --check-auth server validation.
+const (
+	authCheckSkipped = iota
+	authCheckValid
+	authCheckRejected
+	authCheckFailed
+)
 
 var showCmd = &cobra.Command{
 	Use:   "show [name]",
@@ -23,12 +37,16 @@ If no context name is provided, shows the currently active context.
 The output format can be controlled with the --output flag.
 
 The context information includes the directory location, configuration details,
-and authentication status.
+and authentication status. By default the authentication status is derived from
+the locally stored token expiry without contacting the server. Use --check-auth to
+ask the server whether the token is actually still accepted (e.g. it may have been
+revoked server-side).
 
 Examples:
   pb context show                    # Show active context
   pb context show production         # Show specific context
-  pb context show prod --output yaml # Show in YAML format`,
+  pb context show prod --output yaml # Show in YAML format
+  pb context show --check-auth       # Verify the token with the server`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := validateConfigManager(); err != nil {
@@ -60,6 +78,7 @@ Examples:
 				}
 				return fmt.Errorf("context '%s' not found", contextName)
 			}
+			warnContextNameMismatch(contextName, ctx)
 		}
 
 		// Check if it's the active context
@@ -70,10 +89,10 @@ Examples:
 
 		isActive := globalConfig.ActiveContext == contextName
 
-		// Create a display version of the context (hide sensitive data)
-		displayCtx := *ctx
-		if displayCtx.PocketBase.AuthToken != "" {
-			displayCtx.PocketBase.AuthToken = "***HIDDEN***"
+		authCheck := authCheckSkipped
+		var authCheckErr error
+		if showCheckAuth {
+			authCheck, authCheckErr = checkContextAuth(ctx)
 		}
 
 		// Output based on the effective format (falls back to the global default).
@@ -82,23 +101,15 @@ Examples:
 			format = config.Global.OutputFormat
 		}
 		switch strings.ToLower(format) {
-		case "json":
-			output, err := json.MarshalIndent(displayCtx, "", "  ")
-			if err != nil {
-				return fmt.Errorf("failed to marshal context to JSON: %w", err)
-			}
-			fmt.Println(string(output))
-
-		case "yaml":
-			output, err := yaml.Marshal(displayCtx)
-			if err != nil {
-				return fmt.Errorf("failed to marshal context to YAML: %w", err)
+		case config.OutputFormatJSON, config.OutputFormatYAML:
+			if err := outputContext(utils.DataOutput(), ctx, strings.ToLower(format)); err != nil {
+				return err
 			}
-			fmt.Print(string(output))
+			reportAuthCheck(authCheck, authCheckErr)
 
 		case "table", "":
 			// Default table format
-			showContextTable(ctx, isActive, configManager)
+			showContextTable(ctx, isActive, configManager, authCheck, authCheckErr)
 
 		default:
 			return fmt.Errorf("invalid output format '%s'. Valid formats: json, yaml, table",
@@ -109,7 +120,8 @@ Examples:
 	},
 }
 
-func showContextTable(ctx *config.Context, isActive bool, configManager *config.Manager) {
+func showContextTable(ctx *config.Context, isActive bool, configManager *config.Manager, authCheck int, authCheckErr error) {
+	w := utils.DataOutput()
 	green := color.New(color.FgGreen).SprintFunc()
 	yellow := color.New(color.FgYellow).SprintFunc()
 	red := color.New(color.FgRed).SprintFunc()
@@ -117,26 +129,29 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 	bold := color.New(color.Bold).SprintFunc()
 
 	// Header
-	fmt.Printf("%s Context: %s", bold("PocketBase"), cyan(ctx.Name))
+	fmt.Fprintf(w, "%s Context: %s", bold("PocketBase"), cyan(ctx.Name))
 	if isActive {
-		fmt.Printf(" %s", green("(ACTIVE)"))
+		fmt.Fprintf(w, " %s", green("(ACTIVE)"))
 	}
-	fmt.Println()
-	fmt.Println(strings.Repeat("=", 50))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.Repeat("=", 50))
 
 	// Show context directory
 	contextDir := configManager.GetContextDir(ctx.Name)
-	fmt.Printf("Context Directory: %s\n\n", contextDir)
+	fmt.Fprintf(w, "Context Directory: %s\n\n", contextDir)
 
 	// PocketBase Configuration
-	fmt.Printf("%s\n", bold("PocketBase Configuration:"))
-	fmt.Printf("  URL:                %s\n", ctx.PocketBase.URL)
-	fmt.Printf("  Auth Collection:    %s\n", ctx.PocketBase.AuthCollection)
+	fmt.Fprintf(w, "%s\n", bold("PocketBase Configuration:"))
+	fmt.Fprintf(w, "  URL:                %s\n", ctx.PocketBase.URL)
+	fmt.Fprintf(w, "  Auth Collection:    %s\n", ctx.PocketBase.AuthCollection)
 	if ctx.PocketBase.AutoRefresh {
-		fmt.Printf("  Auto-refresh:       %s (threshold: %s)\n",
+		fmt.Fprintf(w, "  Auto-refresh:       %s (threshold: %s)\n",
 			green("enabled"), ctx.PocketBase.GetAutoRefreshThreshold())
+	} else if config.Global.AutoRefreshEnabled() {
+		fmt.Fprintf(w, "  Auto-refresh:       %s (threshold: %s, global default)\n",
+			green("enabled"), config.GlobalAutoRefreshThreshold)
 	} else {
-		fmt.Printf("  Auto-refresh:       %s\n", yellow("disabled"))
+		fmt.Fprintf(w, "  Auto-refresh:       %s\n", yellow("disabled"))
 	}
 
 	// --- START: CORRECTED AUTHENTICATION STATUS LOGIC ---
@@ -146,40 +161,110 @@ func showContextTable(ctx *config.Context, isActive bool, configManager *config.
 		if pocketbase.IsAuthValid(ctx) {
 			expirationInfo := ""
 			if ctx.PocketBase.AuthExpires != nil {
-				expirationInfo = fmt.Sprintf(" (expires %s)", ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05"))
+				expirationInfo = fmt.Sprintf(" (expires %s, in %s)", ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05"),
+					utils.FormatDuration(time.Until(*ctx.PocketBase.AuthExpires)))
 			}
-			fmt.Printf("  Authentication:     %s%s\n", green("Valid"), expirationInfo)
+			fmt.Fprintf(w, "  Authentication:     %s%s\n", green("Valid"), expirationInfo)
 		} else {
 			expirationInfo := ""
 			if ctx.PocketBase.AuthExpires != nil {
 				// Use a more descriptive "expired on" for clarity
 				expirationInfo = fmt.Sprintf(" (expired on %s)", ctx.PocketBase.AuthExpires.Format("2006-01-02 15:04:05"))
 			}
-			fmt.Printf("  Authentication:     %s%s\n", red("Expired"), expirationInfo)
+			fmt.Fprintf(w, "  Authentication:     %s%s\n", red("Expired"), expirationInfo)
 		}
 	} else {
-		fmt.Printf("  Authentication:     %s\n", yellow("Not Authenticated"))
+		fmt.Fprintf(w, "  Authentication:     %s\n", yellow("Not Authenticated"))
 	}
 	// --- END: CORRECTED AUTHENTICATION STATUS LOGIC ---
 
-	fmt.Println()
+	switch authCheck {
+	case authCheckValid:
+		fmt.Fprintf(w, "  Server Check:       %s\n", green("Token accepted"))
+	case authCheckRejected:
+		fmt.Fprintf(w, "  Server Check:       %s\n", red("Token rejected"))
+	case authCheckFailed:
+		fmt.Fprintf(w, "  Server Check:       %s (%v)\n", yellow("Unavailable"), authCheckErr)
+	}
+
+	if len(ctx.PocketBase.CollectionDefaults) > 0 {
+		fmt.Fprintf(w, "\n%s\n", bold("Collection List Defaults:"))
+		names := make([]string, 0, len(ctx.PocketBase.CollectionDefaults))
+		for name := range ctx.PocketBase.CollectionDefaults {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "  %s\n", cyan(name))
+			printCollectionDefaults(w, ctx.PocketBase.CollectionDefaults[name], "    ")
+		}
+	}
+
+	fmt.Fprintln(w)
 
 	// Show helpful commands
 	if !isActive {
-		fmt.Printf("%s\n", bold("Commands:"))
-		fmt.Printf("  Select this context: %s\n",
+		fmt.Fprintf(w, "%s\n", bold("Commands:"))
+		fmt.Fprintf(w, "  Select this context: %s\n",
 			cyan(fmt.Sprintf("pb context select %s", ctx.Name)))
-	} else if ctx.PocketBase.AuthToken == "" || !pocketbase.IsAuthValid(ctx) { // Prompt for auth if not authenticated OR expired
-		fmt.Printf("%s\n", bold("Next Steps:"))
-		fmt.Printf("  Authenticate: %s\n", cyan("pb auth"))
+	} else if ctx.PocketBase.AuthToken == "" || !pocketbase.IsAuthValid(ctx) || authCheck == authCheckRejected { // Prompt for auth if not authenticated OR expired
+		fmt.Fprintf(w, "%s\n", bold("Next Steps:"))
+		fmt.Fprintf(w, "  Authenticate: %s\n", cyan("pb auth"))
 	} else {
-		fmt.Printf("%s\n", bold("Available Operations:"))
-		fmt.Printf("  List collections: %s\n", cyan("pb schema"))
-		fmt.Printf("  List records:     %s\n", cyan("pb collections list <collection>"))
+		fmt.Fprintf(w, "%s\n", bold("Available Operations:"))
+		fmt.Fprintf(w, "  List collections: %s\n", cyan("pb schema"))
+		fmt.Fprintf(w, "  List records:     %s\n", cyan("pb collections list <collection>"))
+	}
+}
+
+// outputContext writes ctx as JSON or YAML through the shared output renderer, with
+// the auth token masked.
+func outputContext(w io.Writer, ctx *config.Context, format string) error {
+	displayCtx := *ctx
+	if displayCtx.PocketBase.AuthToken != "" {
+		displayCtx.PocketBase.AuthToken = "***HIDDEN***"
+	}
+	return utils.OutputDataTo(w, displayCtx, format)
+}
+
+// checkContextAuth validates the context's token against its server. Contexts without a
+// token are reported as skipped since there is nothing to check.
+func checkContextAuth(ctx *config.Context) (int, error) {
+	if ctx.PocketBase.AuthToken == "" {
+		return authCheckSkipped, nil
+	}
+
+	collection := ctx.PocketBase.AuthCollection
+	if collection == "" {
+		collection = config.AuthCollectionUsers
+	}
+
+	valid, err := pocketbase.NewClientFromContext(ctx).ValidateAuth(collection)
+	if err != nil {
+		return authCheckFailed, err
+	}
+	if !valid {
+		return authCheckRejected, nil
+	}
+	return authCheckValid, nil
+}
+
+// reportAuthCheck prints the --check-auth result to stderr for the structured output
+// formats, keeping stdout parseable.
+func reportAuthCheck(authCheck int, authCheckErr error) {
+	switch authCheck {
+	case authCheckValid:
+		fmt.Fprintf(os.Stderr, "%s Server accepted the auth token\n", color.New(color.FgGreen).Sprint("✓"))
+	case authCheckRejected:
+		utils.PrintWarning("server rejected the auth token. Run 'pb auth' to re-authenticate")
+	case authCheckFailed:
+		utils.PrintWarning(fmt.Sprintf("could not check auth with the server: %v", authCheckErr))
 	}
 }
 
 func init() {
 	showCmd.Flags().StringVarP(&showOutputFormat, "output", "o", "",
 		"Output format (json|yaml|table)")
+	showCmd.Flags().BoolVar(&showCheckAuth, "check-auth", false,
+		"Verify the auth token with the server instead of trusting the stored expiry")
 }
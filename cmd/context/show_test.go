@@ -0,0 +1,85 @@
+package context
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+	"pb-cli/internal/utils"
+)
+
+// TestOutputContextMatchesSharedRenderer checks that context show formats JSON and
+// YAML exactly like every other command (utils.OutputDataTo) and masks the token.
+func TestOutputContextMatchesSharedRenderer(t *testing.T) {
+	ctx := &config.Context{
+		Name: "prod",
+		PocketBase: config.PocketBaseConfig{
+			URL:            "https://pb.example.com",
+			AuthCollection: config.AuthCollectionSuperusers,
+			AuthToken:      "secret-token",
+		},
+	}
+
+	for _, format := range []string{config.OutputFormatJSON, config.OutputFormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			var got bytes.Buffer
+			require.NoError(t, outputContext(&got, ctx, format))
+
+			masked := *ctx
+			masked.PocketBase.AuthToken = "***HIDDEN***"
+			var want bytes.Buffer
+			require.NoError(t, utils.OutputDataTo(&want, masked, format))
+
+			assert.Equal(t, want.String(), got.String())
+			assert.NotContains(t, got.String(), "secret-token")
+		})
+	}
+
+	assert.Equal(t, "secret-token", ctx.PocketBase.AuthToken, "the caller's context must not be modified")
+}
+
+// TestShowCheckAuthKeepsStdoutParseable checks that with --check-auth and -o json
+// the auth check result goes to stderr, leaving only the JSON document on stdout.
+func TestShowCheckAuthKeepsStdoutParseable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"token":"fresh-token","record":{"id":"user1"}}`))
+	}))
+	defer srv.Close()
+
+	cm, err := config.NewManagerWithBase(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, cm.SaveContext(&config.Context{
+		Name: "prod",
+		PocketBase: config.PocketBaseConfig{
+			URL:            srv.URL,
+			AuthCollection: config.AuthCollectionUsers,
+			AuthToken:      "secret-token",
+		},
+	}))
+
+	oldManager, oldFormat, oldCheck := configManager, showOutputFormat, showCheckAuth
+	defer func() { configManager, showOutputFormat, showCheckAuth = oldManager, oldFormat, oldCheck }()
+	configManager, showOutputFormat, showCheckAuth = cm, config.OutputFormatJSON, true
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	oldStdout := os.Stdout
+	os.Stdout = w
+	err = showCmd.RunE(showCmd, []string{"prod"})
+	os.Stdout = oldStdout
+	require.NoError(t, w.Close())
+	require.NoError(t, err)
+	stdout, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	var shown map[string]interface{}
+	require.NoError(t, json.Unmarshal(stdout, &shown), "stdout: %s", stdout)
+	assert.Equal(t, "prod", shown["Name"])
+}
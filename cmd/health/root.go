@@ -0,0 +1,142 @@
+package health
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var outputFlag string
+
+// healthReport is the structured result of a health check, suitable for scraping
+// by monitoring systems.
+type healthReport struct {
+	Status    string `json:"status" yaml:"status"`
+	Code      int    `json:"code" yaml:"code"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+	LatencyMS int64  `json:"latency_ms" yaml:"latency_ms"`
+	CanBackup *bool  `json:"canBackup,omitempty" yaml:"canBackup,omitempty"`
+	URL       string `json:"url" yaml:"url"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// HealthCmd represents the health command
+var HealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check whether the active PocketBase instance is healthy",
+	Long: `Check the health of the active context's PocketBase instance and report the
+round-trip latency. The check is a single request: it is never retried, so a
+failure is reported as soon as it happens.
+
+With --output json the result is a single object, so the command can serve as a
+lightweight monitoring probe:
+  {"status":"ok","code":200,"message":"API is healthy.","latency_ms":42,"canBackup":true,"url":"..."}
+
+canBackup is only reported when authenticated as a superuser. The command exits
+non-zero when the instance is unreachable or unhealthy.
+
+Examples:
+  pb health
+  pb health -o json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configManager == nil {
+			return fmt.Errorf("configuration manager not initialized")
+		}
+
+		ctx, err := configManager.GetActiveContext()
+		if err != nil {
+			return fmt.Errorf("no active context set. Use 'pb context select <name>' to set one")
+		}
+
+		// Health needs no auth, but a superuser token adds canBackup to the response.
+		client := pocketbase.NewClientFromContext(ctx)
+
+		report := healthReport{URL: ctx.PocketBase.URL}
+
+		// One attempt, so latency_ms isn't inflated by retries or Retry-After waits.
+		health, latency, err := client.ProbeHealth()
+		report.LatencyMS = latency.Milliseconds()
+
+		if err != nil {
+			report.Status = "error"
+			report.Error = err.Error()
+			if pbErr, ok := err.(*pocketbase.PocketBaseError); ok {
+				report.Code = pbErr.StatusCode
+			}
+		} else {
+			report.Status = "ok"
+			report.Code = health.Code
+			report.Message = health.Message
+			if canBackup, ok := health.Data["canBackup"].(bool); ok {
+				report.CanBackup = &canBackup
+			}
+		}
+
+		format := getOutputFormat()
+		switch format {
+		case config.OutputFormatJSON, config.OutputFormatYAML:
+			if err := utils.OutputData(report, format); err != nil {
+				return err
+			}
+		case config.OutputFormatTable:
+			displayHealth(report)
+		default:
+			return fmt.Errorf("unsupported output format: %s", format)
+		}
+
+		if report.Status != "ok" {
+			return fmt.Errorf("PocketBase at %s is unhealthy", report.URL)
+		}
+		return nil
+	},
+}
+
+var configManager *config.Manager
+
+func init() {
+	HealthCmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Output format (json|yaml|table)")
+}
+
+// SetConfigManager sets the configuration manager for the health command
+func SetConfigManager(cm *config.Manager) {
+	configManager = cm
+}
+
+// getOutputFormat returns the effective output format (flag, else global default).
+func getOutputFormat() string {
+	if outputFlag != "" {
+		return outputFlag
+	}
+	return config.Global.OutputFormat
+}
+
+// displayHealth prints a human-readable health summary.
+func displayHealth(report healthReport) {
+	w := utils.DataOutput()
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+
+	if report.Status == "ok" {
+		fmt.Fprintf(w, "%s PocketBase is healthy (%dms)\n", green("✓"), report.LatencyMS)
+	} else {
+		fmt.Fprintf(w, "%s PocketBase is unhealthy (%dms)\n", red("✗"), report.LatencyMS)
+	}
+	fmt.Fprintf(w, "  URL: %s\n", report.URL)
+	if report.Code != 0 {
+		fmt.Fprintf(w, "  Status: %d\n", report.Code)
+	}
+	if report.Message != "" {
+		fmt.Fprintf(w, "  Message: %s\n", report.Message)
+	}
+	if report.CanBackup != nil {
+		fmt.Fprintf(w, "  Can Backup: %t\n", *report.CanBackup)
+	}
+	if report.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", report.Error)
+	}
+}
@@ -3,27 +3,46 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"pb-cli/cmd/auth"
 	"pb-cli/cmd/backup"
 	"pb-cli/cmd/collections"
+	configcmd "pb-cli/cmd/config"
 	"pb-cli/cmd/context"
+	"pb-cli/cmd/health"
 	"pb-cli/cmd/schema"
+	"pb-cli/cmd/setup"
 	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+	"pb-cli/internal/version"
 )
 
-// version is set by goreleaser via ldflags
-var version = "dev"
-
 var (
 	configManager *config.Manager
 
 	// Global flags
 	globalOutputFormat  string
 	globalColorsEnabled bool
+	globalColorMode     string
+	globalColorJSON     bool
 	globalDebug         bool
+	globalOutputFile    string
+	globalRetries       int
+	globalRetryDelay    time.Duration
+	globalProxy         string
+	globalTimeout       time.Duration
+	globalInsecure      bool
+	globalCACert        string
+	globalProfile       string
+
+	// outputFile is the open --output-file, closed by Execute once the command ends.
+	outputFile *os.File
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -39,7 +58,7 @@ Features:
 - PocketBase authentication with multiple collection support
 - Generic CRUD operations on any collection
 - Backup management (requires admin authentication)`,
-	Version: version,
+	Version: version.Version,
 	// Errors are printed once by main(); don't let cobra also print them or dump
 	// usage on operational (non-parse) failures.
 	SilenceErrors: true,
@@ -69,20 +88,38 @@ Features:
 			}
 		}
 
+		if err := applyProfile(cmd, globalConfig); err != nil {
+			return err
+		}
+
+		if err := normalizeOutputFlag(cmd); err != nil {
+			return err
+		}
+
 		// Apply global config to config.Global, but allow command-line flags to override
-		if !cmd.Flags().Changed("output") {
-			config.Global.OutputFormat = globalConfig.OutputFormat
-		} else {
-			config.Global.OutputFormat = globalOutputFormat
+		if globalConfig.OutputFormat != "" {
+			globalConfig.OutputFormat = strings.ToLower(globalConfig.OutputFormat)
+			if err := config.ValidateOutputFormat(globalConfig.OutputFormat); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: ignoring output_format in global config: %v\n", err)
+				globalConfig.OutputFormat = config.OutputFormatJSON
+			}
+		}
+		config.Global.OutputFormat = resolveOutputFormat(cmd, globalConfig.OutputFormat)
+		if err := config.ValidateOutputFormat(config.Global.OutputFormat); err != nil && flagGiven(cmd, "output") {
+			return err
 		}
 
-		if !cmd.Flags().Changed("colors") {
+		if !flagGiven(cmd, "colors") {
 			config.Global.ColorsEnabled = globalConfig.ColorsEnabled
 		} else {
 			config.Global.ColorsEnabled = globalColorsEnabled
 		}
+		if err := applyColorMode(globalColorMode); err != nil {
+			return err
+		}
+		config.Global.ColorJSON = globalColorJSON
 
-		if !cmd.Flags().Changed("debug") {
+		if !flagGiven(cmd, "debug") {
 			config.Global.Debug = globalConfig.Debug
 		} else {
 			config.Global.Debug = globalDebug
@@ -90,22 +127,190 @@ Features:
 
 		// Apply pagination size (no command line flag for this)
 		config.Global.PaginationSize = globalConfig.PaginationSize
+		config.Global.AuthExpiryBufferSeconds = globalConfig.AuthExpiryBufferSeconds
+		config.Global.AutoRefresh = globalConfig.AutoRefresh
+		config.Global.TypedConfirmThreshold = globalConfig.TypedConfirmThreshold
+		config.Global.LargePayloadWarnBytes = globalConfig.LargePayloadWarnBytes
+		config.Global.LargePayloadLimitBytes = globalConfig.LargePayloadLimitBytes
+		if err := applyRequestSettings(cmd, globalConfig); err != nil {
+			return err
+		}
+		if globalProxy != "" {
+			if err := config.ValidateProxyURL(globalProxy); err != nil {
+				return err
+			}
+		}
+		config.Global.Proxy = globalProxy
+		if err := applyTLSSettings(); err != nil {
+			return err
+		}
+
+		// Redirect formatted output to --output-file.
+		if globalOutputFile != "" {
+			f, err := utils.CreateOutputFile(globalOutputFile)
+			if err != nil {
+				return err
+			}
+			outputFile = f
+			utils.SetDataOutput(f)
+		}
 
 		// Pass config manager to command groups
 		context.SetConfigManager(configManager)
 		auth.SetConfigManager(configManager)
 		backup.SetConfigManager(configManager)
 		collections.SetConfigManager(configManager)
+		configcmd.SetConfigManager(configManager)
 		schema.SetConfigManager(configManager)
+		health.SetConfigManager(configManager)
+		setup.SetConfigManager(configManager)
 
 		return nil
 	},
 }
 
+// profileFlags holds the flags the selected profile set for this run. They are
+// not marked Changed, so commands' conflict checks (such as --query with
+// --output) and context defaults only see flags the user typed; flagGiven
+// still lets them override the global config.
+var profileFlags map[string]bool
+
+// flagGiven reports whether a flag was passed or set by the selected profile.
+func flagGiven(cmd *cobra.Command, name string) bool {
+	return cmd.Flags().Changed(name) || profileFlags[name]
+}
+
+// applyProfile gives every flag set by the selected profile (--profile, else
+// PB_PROFILE) its profile value, unless it was given on the command line.
+// Settings for flags the command doesn't have, such as quiet on list, are
+// skipped.
+func applyProfile(cmd *cobra.Command, globalConfig *config.GlobalConfig) error {
+	profileFlags = nil
+	name, source := os.Getenv(config.ProfileEnvVar), config.ProfileEnvVar
+	if flag := cmd.Flags().Lookup("profile"); flag != nil && flag.Changed {
+		name, source = flag.Value.String(), "--profile"
+	}
+	if name == "" {
+		return nil
+	}
+
+	profile, err := globalConfig.GetProfile(name)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", source, err)
+	}
+	for _, flagName := range profile.Flags() {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := flag.Value.Set(profile[flagName]); err != nil {
+			return fmt.Errorf("profile '%s': invalid value for --%s: %w", name, flagName, err)
+		}
+		if profileFlags == nil {
+			profileFlags = make(map[string]bool)
+		}
+		profileFlags[flagName] = true
+	}
+	return nil
+}
+
+// applyRequestSettings applies --timeout, --retries, and --retry-delay, falling back
+// to the global config's request_timeout, retries, and retry_delay. Invalid config values are ignored with
+// a warning; invalid flags are errors.
+func applyRequestSettings(cmd *cobra.Command, globalConfig *config.GlobalConfig) error {
+	config.Global.Retries = nil
+	config.Global.RetryDelay = ""
+	config.Global.RequestTimeout = ""
+
+	if flagGiven(cmd, "timeout") {
+		if globalTimeout < 0 {
+			return fmt.Errorf("invalid --timeout: must not be negative")
+		}
+		config.Global.RequestTimeout = globalTimeout.String()
+	} else if globalConfig.RequestTimeout != "" {
+		if _, err := config.ParseRequestTimeout(globalConfig.RequestTimeout); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring request_timeout in global config: %v\n", err)
+		} else {
+			config.Global.RequestTimeout = globalConfig.RequestTimeout
+		}
+	}
+
+	if flagGiven(cmd, "retries") {
+		if err := config.ValidateRetries(globalRetries); err != nil {
+			return fmt.Errorf("invalid --retries: %w", err)
+		}
+		config.Global.Retries = &globalRetries
+	} else if globalConfig.Retries != nil {
+		if err := config.ValidateRetries(*globalConfig.Retries); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring retries in global config: %v\n", err)
+		} else {
+			config.Global.Retries = globalConfig.Retries
+		}
+	}
+
+	if flagGiven(cmd, "retry-delay") {
+		if globalRetryDelay <= 0 {
+			return fmt.Errorf("invalid --retry-delay: must be positive")
+		}
+		config.Global.RetryDelay = globalRetryDelay.String()
+	} else if globalConfig.RetryDelay != "" {
+		if _, err := config.ParseRetryDelay(globalConfig.RetryDelay); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring retry_delay in global config: %v\n", err)
+		} else {
+			config.Global.RetryDelay = globalConfig.RetryDelay
+		}
+	}
+	return nil
+}
+
+// applyTLSSettings validates --insecure and --cacert and applies them.
+func applyTLSSettings() error {
+	config.Global.Insecure = globalInsecure
+	config.Global.CACertFile = globalCACert
+	if globalInsecure && globalCACert != "" {
+		return fmt.Errorf("--insecure and --cacert cannot be used together")
+	}
+	if globalCACert != "" {
+		if _, err := pocketbase.LoadCertPool(globalCACert); err != nil {
+			return fmt.Errorf("invalid --cacert: %w", err)
+		}
+	}
+	if globalInsecure {
+		utils.PrintWarning("TLS certificate verification is disabled (--insecure); use --cacert to trust a self-signed certificate instead")
+	}
+	return nil
+}
+
+// applyColorMode validates --color and applies it. fatih/color, used directly by
+// many commands, already disables itself when stdout isn't a terminal; always and
+// never override that check, and disabled colors turn it off entirely.
+func applyColorMode(mode string) error {
+	switch mode {
+	case config.ColorModeAuto:
+	case config.ColorModeAlways:
+		color.NoColor = false
+	case config.ColorModeNever:
+		config.Global.ColorsEnabled = false
+	default:
+		return fmt.Errorf("invalid --color %q: use auto, always, or never", mode)
+	}
+	config.Global.ColorMode = mode
+	if !config.Global.ColorsEnabled {
+		color.NoColor = true
+	}
+	return nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if outputFile != nil {
+		if closeErr := outputFile.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to write output file: %w", closeErr)
+		}
+	}
+	return err
 }
 
 func init() {
@@ -114,7 +319,17 @@ func init() {
 	// Global flags with proper variable binding
 	rootCmd.PersistentFlags().StringVarP(&globalOutputFormat, "output", "o", "json", "Output format (json|yaml|table)")
 	rootCmd.PersistentFlags().BoolVar(&globalColorsEnabled, "colors", true, "Enable colored output")
+	rootCmd.PersistentFlags().StringVar(&globalColorMode, "color", config.ColorModeAuto, "When to color output: auto (only on a terminal), always, or never")
+	rootCmd.PersistentFlags().BoolVar(&globalColorJSON, "color-json", true, "Syntax-highlight JSON output when colors are used (--color-json=false for plain JSON)")
 	rootCmd.PersistentFlags().BoolVar(&globalDebug, "debug", false, "Enable debug output")
+	rootCmd.PersistentFlags().DurationVar(&globalTimeout, "timeout", config.DefaultRequestTimeout, "Timeout for each API request (0 for none); backup transfers are never cut off")
+	rootCmd.PersistentFlags().IntVar(&globalRetries, "retries", config.DefaultRetries, "Retry GET requests this many times after a network blip, 429, or 502/503/504")
+	rootCmd.PersistentFlags().DurationVar(&globalRetryDelay, "retry-delay", config.DefaultRetryDelay, "First wait before a retry; later waits back off exponentially with jitter")
+	rootCmd.PersistentFlags().StringVar(&globalProxy, "proxy", "", "Send all requests through this proxy (http, https, or socks5 URL); defaults to HTTP_PROXY/HTTPS_PROXY")
+	rootCmd.PersistentFlags().BoolVar(&globalInsecure, "insecure", false, "Skip TLS certificate verification (for self-signed certificates; prefer --cacert)")
+	rootCmd.PersistentFlags().StringVar(&globalCACert, "cacert", "", "PEM file of CA certificates to trust in addition to the system ones")
+	rootCmd.PersistentFlags().StringVar(&globalProfile, "profile", "", "Apply a named flag profile from the global config (default $PB_PROFILE)")
+	rootCmd.PersistentFlags().StringVar(&globalOutputFile, "output-file", "", "Write formatted output to this file instead of stdout (parent directories are created)")
 
 	// Bind flags to viper for config file support
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
@@ -127,6 +342,9 @@ func init() {
 
 // addCommands adds all command groups to the root command
 func addCommands() {
+	// First-run setup wizard
+	rootCmd.AddCommand(setup.InitCmd)
+
 	// Context management commands
 	rootCmd.AddCommand(context.ContextCmd)
 
@@ -139,8 +357,40 @@ func addCommands() {
 	// Collections CRUD commands
 	rootCmd.AddCommand(collections.CollectionsCmd)
 
+	// Global settings and profiles
+	rootCmd.AddCommand(configcmd.ConfigCmd)
+
 	// Schema inspection commands
 	rootCmd.AddCommand(schema.SchemaCmd)
+
+	// Instance health probe
+	rootCmd.AddCommand(health.HealthCmd)
+
+	// Build metadata
+	rootCmd.AddCommand(versionCmd)
+}
+
+// normalizeOutputFlag lower-cases a given --output (typed or from a profile) in
+// place, so -o JSON works and each command's own switch on its flag variable sees
+// the canonical format name.
+func normalizeOutputFlag(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil || !flagGiven(cmd, "output") {
+		return nil
+	}
+	return flag.Value.Set(strings.ToLower(flag.Value.String()))
+}
+
+// resolveOutputFormat returns the effective output format for cmd: an explicit
+// --output (or the profile's) wins, otherwise the configured default. Command groups such as backup
+// and collections declare their own --output, which shadows the root flag for
+// their subcommands, so the value is read from cmd's merged flag set rather than
+// from globalOutputFormat, which only the root flag writes to.
+func resolveOutputFormat(cmd *cobra.Command, configured string) string {
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flagGiven(cmd, "output") {
+		return flag.Value.String()
+	}
+	return configured
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+)
+
+// newShadowedOutputTree mirrors how command groups like backup declare their own
+// persistent --output on top of the root one.
+func newShadowedOutputTree() (*cobra.Command, *cobra.Command) {
+	root := &cobra.Command{Use: "pb"}
+	root.PersistentFlags().StringP("output", "o", "json", "")
+
+	group := &cobra.Command{Use: "backup"}
+	group.PersistentFlags().StringP("output", "o", "", "")
+
+	leaf := &cobra.Command{Use: "list", RunE: func(*cobra.Command, []string) error { return nil }}
+	group.AddCommand(leaf)
+	root.AddCommand(group)
+	return root, leaf
+}
+
+func TestResolveOutputFormat(t *testing.T) {
+	testCases := []struct {
+		name       string
+		args       []string
+		configured string
+		expected   string
+	}{
+		{"Configured default", []string{"backup", "list"}, "table", "table"},
+		{"Flag before group", []string{"--output", "json", "backup", "list"}, "table", "json"},
+		{"Flag after subcommand", []string{"backup", "list", "-o", "yaml"}, "table", "yaml"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root, leaf := newShadowedOutputTree()
+			root.SetArgs(tc.args)
+			require.NoError(t, root.Execute())
+			assert.Equal(t, tc.expected, resolveOutputFormat(leaf, tc.configured))
+		})
+	}
+}
+
+// TestNormalizeOutputFlag checks that -o is matched case-insensitively by
+// lower-casing the flag the command itself reads.
+func TestNormalizeOutputFlag(t *testing.T) {
+	root, leaf := newShadowedOutputTree()
+	root.SetArgs([]string{"backup", "list", "-o", "JSON"})
+	require.NoError(t, root.Execute())
+	require.NoError(t, normalizeOutputFlag(leaf))
+	assert.Equal(t, "json", leaf.Flags().Lookup("output").Value.String())
+	assert.Equal(t, "json", resolveOutputFormat(leaf, "table"))
+}
+
+// TestApplyProfile checks that profile settings act like passed flags, that
+// real flags win, and that settings for flags a command lacks are skipped.
+func TestApplyProfile(t *testing.T) {
+	globalConfig := &config.GlobalConfig{Profiles: map[string]config.Profile{
+		"scripting": {"output": "json", "quiet": "true", "colors": "false"},
+	}}
+
+	newTree := func() (*cobra.Command, *cobra.Command) {
+		root, leaf := newShadowedOutputTree()
+		root.PersistentFlags().String("profile", "", "")
+		root.PersistentFlags().Bool("colors", true, "")
+		leaf.Flags().Bool("quiet", false, "")
+		return root, leaf
+	}
+
+	t.Run("Profile flag", func(t *testing.T) {
+		root, leaf := newTree()
+		root.SetArgs([]string{"--profile", "scripting", "backup", "list"})
+		require.NoError(t, root.Execute())
+		require.NoError(t, applyProfile(leaf, globalConfig))
+		assert.Equal(t, "json", resolveOutputFormat(leaf, "table"))
+		assert.Equal(t, "true", leaf.Flags().Lookup("quiet").Value.String())
+		assert.True(t, flagGiven(leaf, "colors"))
+		assert.False(t, leaf.Flags().Changed("colors"), "profile values don't count as typed flags")
+	})
+
+	t.Run("Flags win over the environment profile", func(t *testing.T) {
+		t.Setenv(config.ProfileEnvVar, "scripting")
+		root, leaf := newTree()
+		root.SetArgs([]string{"backup", "list", "-o", "yaml"})
+		require.NoError(t, root.Execute())
+		require.NoError(t, applyProfile(leaf, globalConfig))
+		assert.Equal(t, "yaml", resolveOutputFormat(leaf, "table"))
+		assert.Equal(t, "true", leaf.Flags().Lookup("quiet").Value.String())
+	})
+
+	t.Run("Unknown profile", func(t *testing.T) {
+		t.Setenv(config.ProfileEnvVar, "human")
+		root, leaf := newTree()
+		root.SetArgs([]string{"backup", "list"})
+		require.NoError(t, root.Execute())
+		assert.ErrorContains(t, applyProfile(leaf, globalConfig), "invalid PB_PROFILE")
+	})
+}
+
+// TestProfileOutputWithQuery runs the real command tree: a profile's output must
+// not count as a typed --output, which get rejects alongside --query.
+func TestProfileOutputWithQuery(t *testing.T) {
+	oldHome := xdg.ConfigHome
+	xdg.ConfigHome = t.TempDir()
+	t.Cleanup(func() { xdg.ConfigHome = oldHome })
+
+	configDir := filepath.Join(xdg.ConfigHome, "pb")
+	require.NoError(t, os.MkdirAll(configDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "config.yaml"),
+		[]byte("output_format: json\nprofiles:\n  scripting:\n    output: yaml\n"), 0o600))
+	t.Setenv(config.ProfileEnvVar, "scripting")
+
+	rootCmd.SetArgs([]string{"collections", "get", "posts", "abc123def456ghi", "--query", "title"})
+	t.Cleanup(func() { rootCmd.SetArgs(nil) })
+	err := rootCmd.Execute()
+
+	// With no context configured, get stops at the context check, past the
+	// --query/--output conflict check.
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "--query")
+	assert.Contains(t, err.Error(), "no active context")
+	assert.True(t, profileFlags["output"])
+	assert.Equal(t, "yaml", config.Global.OutputFormat)
+}
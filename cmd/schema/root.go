@@ -94,6 +94,7 @@ func validateActiveContext() (*config.Context, error) {
 
 // listCollections prints every collection on the instance.
 func listCollections(client *pocketbase.Client) error {
+	w := utils.DataOutput()
 	collections, err := client.GetCollections()
 	if err != nil {
 		return superuserError(err, "read collections")
@@ -105,7 +106,7 @@ func listCollections(client *pocketbase.Client) error {
 	}
 
 	if len(collections) == 0 {
-		fmt.Println("No collections found.")
+		fmt.Fprintln(w, "No collections found.")
 		return nil
 	}
 
@@ -113,13 +114,14 @@ func listCollections(client *pocketbase.Client) error {
 	for _, c := range collections {
 		table.Append([]string{c.Name, c.Type, fmt.Sprintf("%d", len(c.Fields))})
 	}
-	fmt.Printf("Collections (%d):\n", len(collections))
+	fmt.Fprintf(w, "Collections (%d):\n", len(collections))
 	table.Render()
 	return nil
 }
 
 // showCollection prints the fields and rules for a single collection.
 func showCollection(client *pocketbase.Client, name string) error {
+	w := utils.DataOutput()
 	collection, err := client.GetCollectionSchema(name)
 	if err != nil {
 		return superuserError(err, "read collection schema")
@@ -131,7 +133,7 @@ func showCollection(client *pocketbase.Client, name string) error {
 	}
 
 	bold := color.New(color.Bold).SprintFunc()
-	fmt.Printf("%s Collection: %s (%s)\n", bold("PocketBase"), collection.Name, collection.Type)
+	fmt.Fprintf(w, "%s Collection: %s (%s)\n", bold("PocketBase"), collection.Name, collection.Type)
 
 	table := newTable("FIELD", "TYPE", "REQUIRED")
 	for _, f := range collection.Fields {
@@ -146,7 +148,8 @@ func showCollection(client *pocketbase.Client, name string) error {
 // printRules shows the access rules. A nil rule means "superusers only"; an empty
 // (non-nil) rule means "public".
 func printRules(c *pocketbase.Collection) {
-	fmt.Printf("\nAccess rules:\n")
+	w := utils.DataOutput()
+	fmt.Fprintf(w, "\nAccess rules:\n")
 	rules := []struct {
 		label string
 		rule  *string
@@ -158,7 +161,7 @@ func printRules(c *pocketbase.Collection) {
 		{"delete", c.DeleteRule},
 	}
 	for _, r := range rules {
-		fmt.Printf("  %-7s %s\n", r.label+":", formatRule(r.rule))
+		fmt.Fprintf(w, "  %-7s %s\n", r.label+":", formatRule(r.rule))
 	}
 }
 
@@ -199,7 +202,7 @@ func superuserError(err error, action string) error {
 
 // newTable builds a borderless table matching the style used elsewhere in the CLI.
 func newTable(headers ...string) *tablewriter.Table {
-	table := tablewriter.NewWriter(os.Stdout)
+	table := tablewriter.NewWriter(utils.DataOutput())
 	table.SetHeader(headers)
 	table.SetBorder(false)
 	table.SetHeaderLine(false)
@@ -0,0 +1,221 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+	"pb-cli/internal/utils"
+)
+
+var (
+	initName           string
+	initURL            string
+	initAuthCollection string
+	initEmail          string
+	initPassword       string
+	initNonInteractive bool
+)
+
+// InitCmd represents the init command, a first-run setup wizard
+var InitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactive first-run setup",
+	Long: `Set up pb in one step: create a context, select it, and authenticate.
+
+The wizard prompts for:
+  1. A context name
+  2. The PocketBase URL (the connection is tested before continuing)
+  3. The auth collection (users, _superusers, or a custom one)
+  4. Credentials (leave the email empty to skip authentication)
+
+When authenticated as a superuser, the collections on the instance are listed
+so you can start working with them right away.
+
+Use --non-interactive to drive the same steps from flags in scripts. Credentials
+may also come from PB_EMAIL and PB_PASSWORD; authentication is skipped when no
+email is available.
+
+Examples:
+  pb init
+  pb init --non-interactive --name local --url http://localhost:8090
+  PB_PASSWORD=secret pb init --non-interactive --name prod \
+    --url https://api.example.com --auth-collection _superusers --email admin@example.com`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configManager == nil {
+			return fmt.Errorf("configuration manager not initialized")
+		}
+
+		var err error
+
+		// Step 1: context name
+		if initName == "" {
+			if initNonInteractive {
+				return fmt.Errorf("--name is required with --non-interactive")
+			}
+			if initName, err = utils.Prompt("Context name", "default"); err != nil {
+				return err
+			}
+		}
+		if err := configManager.ValidateContextName(initName); err != nil {
+			return err
+		}
+		if configManager.ContextExists(initName) {
+			return fmt.Errorf("context '%s' already exists. Use 'pb context select %s' or choose another name", initName, initName)
+		}
+
+		// Step 2: URL, validated and reachable
+		if initURL == "" {
+			if initNonInteractive {
+				return fmt.Errorf("--url is required with --non-interactive")
+			}
+			if initURL, err = utils.Prompt("PocketBase URL", "http://localhost:8090"); err != nil {
+				return err
+			}
+		}
+		initURL = strings.TrimRight(initURL, "/")
+		if err := utils.ValidatePocketBaseURL(initURL); err != nil {
+			return fmt.Errorf("invalid URL: %w", err)
+		}
+
+		client := pocketbase.NewClient(initURL)
+		utils.PrintInfo("Testing connection to PocketBase...")
+		if err := client.GetHealth(); err != nil {
+			return fmt.Errorf("failed to connect to PocketBase at %s: %w", initURL, err)
+		}
+
+		// Step 3: auth collection
+		if initAuthCollection == "" {
+			if initNonInteractive {
+				initAuthCollection = config.AuthCollectionUsers
+			} else if initAuthCollection, err = utils.Prompt("Auth collection", config.AuthCollectionUsers); err != nil {
+				return err
+			}
+		}
+		if err := config.ValidateAuthCollection(initAuthCollection); err != nil {
+			return err
+		}
+
+		// Create and select the context before authenticating so a failed login
+		// still leaves a usable context behind.
+		ctx := &config.Context{
+			Name: initName,
+			PocketBase: config.PocketBaseConfig{
+				URL:            initURL,
+				AuthCollection: initAuthCollection,
+			},
+		}
+		if err := configManager.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to save context: %w", err)
+		}
+		if err := configManager.SetActiveContext(initName); err != nil {
+			return fmt.Errorf("failed to set active context: %w", err)
+		}
+
+		green := color.New(color.FgGreen).SprintFunc()
+		cyan := color.New(color.FgCyan).SprintFunc()
+		fmt.Printf("%s Context '%s' created and selected\n", green("✓"), initName)
+
+		// Step 4: credentials
+		email, password, err := resolveCredentials()
+		if err != nil {
+			return err
+		}
+		if email == "" {
+			fmt.Printf("\nSkipped authentication. When ready, run: %s\n", cyan("pb auth"))
+			return nil
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Authenticating with collection '%s'...", initAuthCollection))
+		authResp, err := client.Authenticate(initAuthCollection, email, password)
+		if err != nil {
+			return fmt.Errorf("context saved, but authentication failed (retry with 'pb auth'): %w", err)
+		}
+		if err := pocketbase.UpdateAuthContextFromResponse(ctx, authResp); err != nil {
+			return fmt.Errorf("failed to update context: %w", err)
+		}
+		if err := configManager.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to save authentication: %w", err)
+		}
+		fmt.Printf("%s Authenticated as %s\n", green("✓"), email)
+
+		// Step 5: discover collections (superuser-only endpoint)
+		if initAuthCollection == config.AuthCollectionSuperusers {
+			collections, err := client.GetCollections()
+			if err != nil {
+				utils.PrintWarning(fmt.Sprintf("could not list collections: %v", err))
+			} else if len(collections) > 0 {
+				fmt.Printf("\nCollections on this instance:\n")
+				for _, c := range collections {
+					if !c.System {
+						fmt.Printf("  %s (%s)\n", c.Name, c.Type)
+					}
+				}
+			}
+		}
+
+		fmt.Printf("\nYou're ready to go:\n")
+		fmt.Printf("  List collections: %s\n", cyan("pb schema"))
+		fmt.Printf("  List records:     %s\n", cyan("pb collections list <collection>"))
+
+		return nil
+	},
+}
+
+var configManager *config.Manager
+
+func init() {
+	InitCmd.Flags().StringVar(&initName, "name", "", "Context name")
+	InitCmd.Flags().StringVar(&initURL, "url", "", "PocketBase server URL")
+	InitCmd.Flags().StringVar(&initAuthCollection, "auth-collection", "", "Auth collection (defaults to 'users')")
+	InitCmd.Flags().StringVarP(&initEmail, "email", "e", "", "Email address (or set PB_EMAIL)")
+	InitCmd.Flags().StringVarP(&initPassword, "password", "p", "", "Password (insecure in shell history; prefer PB_PASSWORD)")
+	InitCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Never prompt; take all values from flags and environment")
+}
+
+// SetConfigManager sets the configuration manager for the init command
+func SetConfigManager(cm *config.Manager) {
+	configManager = cm
+}
+
+// resolveCredentials returns the email and password to authenticate with, resolving
+// each as flag > environment > prompt. An empty email means authentication is skipped.
+func resolveCredentials() (string, string, error) {
+	email := initEmail
+	if email == "" {
+		email = os.Getenv("PB_EMAIL")
+	}
+	if email == "" && !initNonInteractive {
+		var err error
+		if email, err = utils.Prompt("Email (leave empty to skip authentication)", ""); err != nil {
+			return "", "", err
+		}
+	}
+	if email == "" {
+		return "", "", nil
+	}
+	if err := utils.ValidateEmail(email); err != nil {
+		return "", "", err
+	}
+
+	password := initPassword
+	if password == "" {
+		password = os.Getenv("PB_PASSWORD")
+	}
+	if password == "" {
+		if initNonInteractive {
+			return "", "", fmt.Errorf("a password is required to authenticate: pass --password or set PB_PASSWORD")
+		}
+		var err error
+		if password, err = utils.PromptPassword("Password"); err != nil {
+			return "", "", err
+		}
+	}
+
+	return email, password, nil
+}
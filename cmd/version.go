@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"pb-cli/internal/version"
+)
+
+var versionShortFlag bool
+
+// versionCmd prints the build metadata stamped into the binary.
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show the pb version",
+	Long: `Show the pb version, commit, and build date.
+
+Use --short to print only the version number, e.g. for scripts that check
+for a minimum version.
+
+Examples:
+  pb version
+  pb version --short`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if versionShortFlag {
+			fmt.Println(version.Version)
+			return nil
+		}
+		fmt.Printf("pb %s\n", version.String())
+		return nil
+	},
+}
+
+func init() {
+	versionCmd.Flags().BoolVar(&versionShortFlag, "short", false, "Print only the version number")
+}
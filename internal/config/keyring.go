@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService names the entries pb stores in the OS keyring. Each context's
+// token is saved under this service with the context name as the account.
+const keyringService = "pb-cli"
+
+// ErrTokenNotFound is returned by a TokenStore when it holds no token for a context.
+var ErrTokenNotFound = errors.New("token not found in keyring")
+
+// TokenStore keeps auth tokens outside the context files. It is used instead of
+// the plaintext auth_token field when secure_token_storage is enabled.
+type TokenStore interface {
+	Get(contextName string) (string, error)
+	Set(contextName, token string) error
+	Delete(contextName string) error
+}
+
+// SystemKeyring stores tokens in the OS keyring through the platform's own tool:
+// the login Keychain via security(1) on macOS, and the Secret Service (GNOME
+// Keyring, KWallet) via secret-tool on Linux. Other platforms, including Windows,
+// are unsupported and report an error asking to turn secure_token_storage off.
+type SystemKeyring struct{}
+
+// Get returns the token saved for contextName, or ErrTokenNotFound.
+func (SystemKeyring) Get(contextName string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", contextName, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", contextName)
+	default:
+		return "", errKeyringUnsupported()
+	}
+
+	out, err := runKeyringCommand(cmd, "")
+	if err != nil {
+		// Both tools exit non-zero when no matching entry exists.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", ErrTokenNotFound
+		}
+		return "", err
+	}
+
+	token := strings.TrimRight(out, "\r\n")
+	if token == "" {
+		return "", ErrTokenNotFound
+	}
+	return token, nil
+}
+
+// Set saves token for contextName, replacing any existing entry.
+func (SystemKeyring) Set(contextName, token string) error {
+	var cmd *exec.Cmd
+	stdin := ""
+	switch runtime.GOOS {
+	case "darwin":
+		// add-generic-password only takes the secret as an argument, so feed the
+		// command to security's interactive mode on stdin to keep the token out
+		// of the process list. -U updates an existing entry in place.
+		cmd = exec.Command("security", "-i")
+		stdin = securityCommandLine("add-generic-password", "-U", "-s", keyringService, "-a", contextName, "-w", token)
+	case "linux":
+		cmd = exec.Command("secret-tool", "store", "--label", fmt.Sprintf("pb-cli token (%s)", contextName),
+			"service", keyringService, "account", contextName)
+		stdin = token
+	default:
+		return errKeyringUnsupported()
+	}
+
+	if _, err := runKeyringCommand(cmd, stdin); err != nil {
+		return fmt.Errorf("failed to store token in keyring: %w", err)
+	}
+	return nil
+}
+
+// securityCommandLine builds one line for `security -i`, double-quoting each
+// argument so context names with spaces or quotes survive its word splitting.
+func securityCommandLine(args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		arg = strings.ReplaceAll(arg, `\`, `\\`)
+		arg = strings.ReplaceAll(arg, `"`, `\"`)
+		quoted[i] = `"` + arg + `"`
+	}
+	return strings.Join(quoted, " ") + "\n"
+}
+
+// Delete removes the token saved for contextName. A missing entry is not an error.
+func (SystemKeyring) Delete(contextName string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", contextName)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", keyringService, "account", contextName)
+	default:
+		return errKeyringUnsupported()
+	}
+
+	if _, err := runKeyringCommand(cmd, ""); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil
+		}
+		return fmt.Errorf("failed to remove token from keyring: %w", err)
+	}
+	return nil
+}
+
+// runKeyringCommand runs a keyring tool, returning its stdout. A tool that isn't
+// installed is reported with what to install or how to opt out.
+func runKeyringCommand(cmd *exec.Cmd, stdin string) (string, error) {
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s not found; install it (e.g. libsecret-tools) or set secure_token_storage: false", cmd.Path)
+		}
+		return "", err
+	}
+	// In interactive mode security can exit 0 after a failed command, so treat
+	// anything it reports on stderr as the failure.
+	if len(cmd.Args) == 2 && cmd.Args[1] == "-i" {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", errors.New(msg)
+		}
+	}
+	return stdout.String(), nil
+}
+
+func errKeyringUnsupported() error {
+	return fmt.Errorf("secure_token_storage is not supported on %s; set secure_token_storage: false", runtime.GOOS)
+}
@@ -11,7 +11,8 @@ import (
 
 // Manager handles configuration and context management
 type Manager struct {
-	configDir string
+	configDir  string
+	tokenStore TokenStore
 }
 
 // NewManager creates a new configuration manager
@@ -26,10 +27,24 @@ func NewManager() (*Manager, error) {
 	}
 
 	return &Manager{
-		configDir: configDir,
+		configDir:  configDir,
+		tokenStore: SystemKeyring{},
 	}, nil
 }
 
+// SetTokenStore replaces the keyring used when secure_token_storage is enabled.
+// This is primarily used for testing.
+func (m *Manager) SetTokenStore(store TokenStore) {
+	m.tokenStore = store
+}
+
+// secureTokenStorage reports whether tokens are kept in the keyring rather than
+// in context files.
+func (m *Manager) secureTokenStorage() bool {
+	globalConfig, err := m.LoadGlobalConfig()
+	return err == nil && globalConfig.SecureTokenStorage
+}
+
 // GetConfigDir returns the main configuration directory path
 func (m *Manager) GetConfigDir() string {
 	return m.configDir
@@ -50,6 +65,11 @@ func (m *Manager) GetContextPath(name string) string {
 	return filepath.Join(m.GetContextDir(name), "context.yaml")
 }
 
+// GetFilterPresetsPath returns the path to a context's saved filter presets
+func (m *Manager) GetFilterPresetsPath(name string) string {
+	return filepath.Join(m.GetContextDir(name), "filters.yaml")
+}
+
 // GetBackupDir returns the backup directory for a specific context
 func (m *Manager) GetBackupDir(name string) string {
 	return filepath.Join(m.GetContextDir(name), "backups")
@@ -132,9 +152,29 @@ func (m *Manager) LoadContext(name string) (*Context, error) {
 		return nil, fmt.Errorf("failed to parse context file: %w", err)
 	}
 
+	if m.secureTokenStorage() {
+		m.loadKeyringToken(&context)
+	}
+
 	return &context, nil
 }
 
+// loadKeyringToken fills in the context's token from the keyring. A plaintext
+// token left in the file from before secure_token_storage was enabled is moved
+// into the keyring and removed from the file. Keyring failures are not fatal here:
+// the context loads without a token (or keeps its plaintext one), so commands ask
+// for 'pb auth', whose SaveContext reports the keyring problem.
+func (m *Manager) loadKeyringToken(context *Context) {
+	if context.PocketBase.AuthToken != "" {
+		m.SaveContext(context)
+		return
+	}
+
+	if token, err := m.tokenStore.Get(context.Name); err == nil {
+		context.PocketBase.AuthToken = token
+	}
+}
+
 // SaveContext saves a context configuration
 func (m *Manager) SaveContext(context *Context) error {
 	if context.Name == "" {
@@ -155,12 +195,28 @@ func (m *Manager) SaveContext(context *Context) error {
 	// Save context configuration
 	contextPath := m.GetContextPath(context.Name)
 
-	data, err := yaml.Marshal(context)
+	// With secure storage the token goes to the keyring and the file keeps none.
+	toWrite := context
+	if m.secureTokenStorage() {
+		if context.PocketBase.AuthToken != "" {
+			if err := m.tokenStore.Set(context.Name, context.PocketBase.AuthToken); err != nil {
+				return err
+			}
+		} else if err := m.tokenStore.Delete(context.Name); err != nil {
+			return err
+		}
+		stripped := *context
+		stripped.PocketBase.AuthToken = ""
+		toWrite = &stripped
+	}
+
+	data, err := yaml.Marshal(toWrite)
 	if err != nil {
 		return fmt.Errorf("failed to marshal context: %w", err)
 	}
 
-	// 0600: the context file contains the plaintext auth token.
+	// 0600: the context file contains the plaintext auth token unless it is kept
+	// in the keyring.
 	if err := os.WriteFile(contextPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write context file: %w", err)
 	}
@@ -168,6 +224,44 @@ func (m *Manager) SaveContext(context *Context) error {
 	return nil
 }
 
+// LoadFilterPresets loads a context's saved filter presets. A context without a
+// presets file has none, which is not an error.
+func (m *Manager) LoadFilterPresets(name string) (FilterPresets, error) {
+	data, err := os.ReadFile(m.GetFilterPresetsPath(name))
+	if os.IsNotExist(err) {
+		return FilterPresets{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read filter presets: %w", err)
+	}
+
+	presets := FilterPresets{}
+	if err := yaml.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("failed to parse filter presets: %w", err)
+	}
+
+	return presets, nil
+}
+
+// SaveFilterPresets saves a context's filter presets
+func (m *Manager) SaveFilterPresets(name string, presets FilterPresets) error {
+	if !m.ContextExists(name) {
+		return fmt.Errorf("context '%s' not found", name)
+	}
+
+	data, err := yaml.Marshal(presets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal filter presets: %w", err)
+	}
+
+	// 0600 like the context file; filters can contain user data such as emails.
+	if err := os.WriteFile(m.GetFilterPresetsPath(name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write filter presets: %w", err)
+	}
+
+	return nil
+}
+
 // ListContexts returns all available context names
 func (m *Manager) ListContexts() ([]string, error) {
 	// Read all directories in the config directory
@@ -193,6 +287,67 @@ func (m *Manager) ListContexts() ([]string, error) {
 	return contexts, nil
 }
 
+// ContextNameMismatch is a context directory whose context.yaml names a different
+// context, typically after copying a context directory by hand. Saving such a
+// context writes to the directory of the name inside the file, not the one it
+// was loaded from.
+type ContextNameMismatch struct {
+	Dir  string // directory name, as listed and selected
+	Name string // name field inside context.yaml
+}
+
+// FindContextNameMismatches reports every context whose directory name differs
+// from the name inside its context file. Unreadable contexts are skipped.
+func (m *Manager) FindContextNameMismatches() ([]ContextNameMismatch, error) {
+	contexts, err := m.ListContexts()
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []ContextNameMismatch
+	for _, dir := range contexts {
+		data, err := os.ReadFile(m.GetContextPath(dir))
+		if err != nil {
+			continue
+		}
+		var context Context
+		if err := yaml.Unmarshal(data, &context); err != nil {
+			continue
+		}
+		if context.Name != dir {
+			mismatches = append(mismatches, ContextNameMismatch{Dir: dir, Name: context.Name})
+		}
+	}
+	return mismatches, nil
+}
+
+// RepairContextName renames a mismatched context directory to the name inside
+// its context file, and follows it with the active context if it was active. It
+// fails rather than overwrite when a directory with that name already exists.
+func (m *Manager) RepairContextName(mismatch ContextNameMismatch) error {
+	if err := m.ValidateContextName(mismatch.Name); err != nil {
+		return fmt.Errorf("cannot rename '%s' to '%s': %w", mismatch.Dir, mismatch.Name, err)
+	}
+	if _, err := os.Stat(m.GetContextDir(mismatch.Name)); err == nil {
+		return fmt.Errorf("cannot rename '%s' to '%s': a context directory with that name already exists; edit the name in %s instead",
+			mismatch.Dir, mismatch.Name, m.GetContextPath(mismatch.Dir))
+	}
+
+	if err := os.Rename(m.GetContextDir(mismatch.Dir), m.GetContextDir(mismatch.Name)); err != nil {
+		return fmt.Errorf("failed to rename context directory: %w", err)
+	}
+
+	globalConfig, err := m.LoadGlobalConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	if globalConfig.ActiveContext == mismatch.Dir {
+		globalConfig.ActiveContext = mismatch.Name
+		return m.SaveGlobalConfig(globalConfig)
+	}
+	return nil
+}
+
 // DeleteContext removes a context configuration and its directory
 func (m *Manager) DeleteContext(name string) error {
 	if name == "" {
@@ -205,6 +360,12 @@ func (m *Manager) DeleteContext(name string) error {
 		return fmt.Errorf("context '%s' not found", name)
 	}
 
+	if m.secureTokenStorage() {
+		if err := m.tokenStore.Delete(name); err != nil {
+			return err
+		}
+	}
+
 	// Remove the entire context directory (including backups)
 	if err := os.RemoveAll(contextDir); err != nil {
 		return fmt.Errorf("failed to delete context directory: %w", err)
@@ -284,6 +445,7 @@ func NewManagerWithBase(baseDir string) (*Manager, error) {
 	}
 
 	return &Manager{
-		configDir: baseDir,
+		configDir:  baseDir,
+		tokenStore: SystemKeyring{},
 	}, nil
 }
@@ -2,16 +2,243 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
 // GlobalConfig represents the global CLI configuration
 type GlobalConfig struct {
-	ActiveContext  string `yaml:"active_context"`
-	OutputFormat   string `yaml:"output_format"` // json|yaml|table
-	ColorsEnabled  bool   `yaml:"colors_enabled"`
-	PaginationSize int    `yaml:"pagination_size"`
-	Debug          bool   `yaml:"debug"`
+	ActiveContext string `yaml:"active_context"`
+	OutputFormat  string `yaml:"output_format"` // json|yaml|table
+	ColorsEnabled bool   `yaml:"colors_enabled"`
+
+	// ColorMode is the --color setting: auto (color only on terminals), always,
+	// or never. It is not stored in the config file.
+	ColorMode string `yaml:"-"`
+
+	// ColorJSON highlights JSON output on terminals where colors are used. It is
+	// set by --color-json and not stored in the config file.
+	ColorJSON bool `yaml:"-"`
+
+	PaginationSize int  `yaml:"pagination_size"`
+	Debug          bool `yaml:"debug"`
+
+	// AuthExpiryBufferSeconds treats a token as expired this many seconds early, as a
+	// safety margin for skewed clocks. 0 (the default) trusts the expiry exactly.
+	AuthExpiryBufferSeconds int `yaml:"auth_expiry_buffer_seconds"`
+
+	// SecureTokenStorage keeps auth tokens in the OS keyring instead of the
+	// plaintext auth_token field of each context file.
+	SecureTokenStorage bool `yaml:"secure_token_storage"`
+
+	// AutoRefresh refreshes a still-valid token shortly before it expires, for
+	// contexts that haven't opted into their own auto_refresh. Unset means enabled.
+	AutoRefresh *bool `yaml:"auto_refresh,omitempty"`
+
+	// Retries is how many times a GET is retried after a transient failure; unset
+	// means DefaultRetries. RetryDelay is the first backoff wait as a duration
+	// string; empty means DefaultRetryDelay.
+	Retries    *int   `yaml:"retries,omitempty"`
+	RetryDelay string `yaml:"retry_delay,omitempty"`
+
+	// RequestTimeout bounds each ordinary API call, as a duration string; empty
+	// means DefaultRequestTimeout and "0s" disables it. Backup transfers are never
+	// bounded by it.
+	RequestTimeout string `yaml:"request_timeout,omitempty"`
+
+	// TypedConfirmThreshold is how many records a bulk delete may remove with a
+	// y/N confirmation; above it the collection name must be typed. Unset means
+	// DefaultTypedConfirmThreshold and 0 always asks for the name.
+	TypedConfirmThreshold *int `yaml:"typed_confirm_threshold,omitempty"`
+
+	// LargePayloadWarnBytes and LargePayloadLimitBytes are the record body sizes
+	// above which create/update warn, and refuse without --allow-large. Unset
+	// means the defaults and 0 turns the check off.
+	LargePayloadWarnBytes  *int64 `yaml:"large_payload_warn_bytes,omitempty"`
+	LargePayloadLimitBytes *int64 `yaml:"large_payload_limit_bytes,omitempty"`
+
+	// Proxy is the --proxy URL all requests go through. Empty means the standard
+	// HTTP_PROXY, HTTPS_PROXY, and NO_PROXY variables apply.
+	Proxy string `yaml:"-"`
+
+	// Insecure (--insecure) skips TLS certificate verification. CACertFile
+	// (--cacert) is a PEM bundle trusted in addition to the system roots.
+	Insecure   bool   `yaml:"-"`
+	CACertFile string `yaml:"-"`
+
+	// Profiles are named sets of flag values selected with --profile or
+	// PB_PROFILE, such as a "scripting" profile with output: json.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// ProfileEnvVar selects a profile when --profile is not given.
+const ProfileEnvVar = "PB_PROFILE"
+
+// Profile maps flag names (without dashes) to the values a profile gives them.
+// Flags given on the command line still win.
+type Profile map[string]string
+
+// Flags returns the profile's flag names in sorted order.
+func (p Profile) Flags() []string {
+	flags := make([]string, 0, len(p))
+	for flag := range p {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	return flags
+}
+
+// ProfileNames returns the configured profile names in sorted order.
+func (g *GlobalConfig) ProfileNames() []string {
+	names := make([]string, 0, len(g.Profiles))
+	for name := range g.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetProfile returns the named profile.
+func (g *GlobalConfig) GetProfile(name string) (Profile, error) {
+	if profile, ok := g.Profiles[name]; ok {
+		return profile, nil
+	}
+	if len(g.Profiles) == 0 {
+		return nil, fmt.Errorf("profile '%s' not found; none are configured (add one with 'pb config profile set')", name)
+	}
+	return nil, fmt.Errorf("profile '%s' not found (available: %s)", name, strings.Join(g.ProfileNames(), ", "))
+}
+
+// Defaults for retrying transient API failures.
+const (
+	DefaultRetries    = 2
+	DefaultRetryDelay = time.Second
+	// MaxRetries bounds retries so a misconfiguration can't stall a command.
+	MaxRetries = 10
+
+	// DefaultRequestTimeout bounds ordinary API calls so a dead server fails fast.
+	DefaultRequestTimeout = 30 * time.Second
+
+	// DefaultTypedConfirmThreshold is the largest bulk delete confirmed with y/N.
+	DefaultTypedConfirmThreshold = 50
+
+	// Default record body sizes above which create/update warn and refuse. Bodies
+	// this big usually mean a file's contents ended up in a text field by mistake.
+	DefaultLargePayloadWarnBytes  = 1 << 20
+	DefaultLargePayloadLimitBytes = 10 << 20
+)
+
+// AutoRefreshEnabled reports whether global auto-refresh is on; it defaults to true.
+func (g *GlobalConfig) AutoRefreshEnabled() bool {
+	return g.AutoRefresh == nil || *g.AutoRefresh
+}
+
+// RetryCount returns the configured number of retries, or DefaultRetries.
+func (g *GlobalConfig) RetryCount() int {
+	if g.Retries == nil {
+		return DefaultRetries
+	}
+	return *g.Retries
+}
+
+// TypedConfirmationThreshold returns the configured typed-confirmation threshold, or
+// DefaultTypedConfirmThreshold when unset or negative.
+func (g *GlobalConfig) TypedConfirmationThreshold() int {
+	if g.TypedConfirmThreshold == nil || *g.TypedConfirmThreshold < 0 {
+		return DefaultTypedConfirmThreshold
+	}
+	return *g.TypedConfirmThreshold
+}
+
+// LargePayloadWarnSize returns the configured payload warning size, or
+// DefaultLargePayloadWarnBytes when unset or negative. 0 means never warn.
+func (g *GlobalConfig) LargePayloadWarnSize() int64 {
+	return byteSetting(g.LargePayloadWarnBytes, DefaultLargePayloadWarnBytes)
+}
+
+// LargePayloadLimitSize returns the configured payload limit, or
+// DefaultLargePayloadLimitBytes when unset or negative. 0 means no limit.
+func (g *GlobalConfig) LargePayloadLimitSize() int64 {
+	return byteSetting(g.LargePayloadLimitBytes, DefaultLargePayloadLimitBytes)
+}
+
+func byteSetting(value *int64, fallback int64) int64 {
+	if value == nil || *value < 0 {
+		return fallback
+	}
+	return *value
+}
+
+// RetryWait returns the configured first retry delay, or DefaultRetryDelay when
+// unset or invalid.
+func (g *GlobalConfig) RetryWait() time.Duration {
+	if d, err := ParseRetryDelay(g.RetryDelay); err == nil && d > 0 {
+		return d
+	}
+	return DefaultRetryDelay
+}
+
+// RequestTimeoutDuration returns the configured API request timeout, or
+// DefaultRequestTimeout when unset or invalid. 0 means no timeout.
+func (g *GlobalConfig) RequestTimeoutDuration() time.Duration {
+	if g.RequestTimeout == "" {
+		return DefaultRequestTimeout
+	}
+	if d, err := ParseRequestTimeout(g.RequestTimeout); err == nil {
+		return d
+	}
+	return DefaultRequestTimeout
+}
+
+// ParseRequestTimeout parses a request timeout such as "2m"; "0s" disables it.
+func ParseRequestTimeout(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid request timeout %q: %w", value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("request timeout must not be negative, got %s", value)
+	}
+	return d, nil
+}
+
+// ValidateRetries checks a retry count is within 0..MaxRetries.
+func ValidateRetries(retries int) error {
+	if retries < 0 || retries > MaxRetries {
+		return fmt.Errorf("retries must be between 0 and %d, got %d", MaxRetries, retries)
+	}
+	return nil
+}
+
+// ValidateProxyURL checks a --proxy value is an absolute http, https, or socks5 URL.
+func ValidateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy URL %q: scheme must be http, https, or socks5", proxy)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: missing host", proxy)
+	}
+	return nil
+}
+
+// ParseRetryDelay parses a retry delay such as "500ms" or "2s". It must be positive.
+func ParseRetryDelay(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retry delay %q: %w", value, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("retry delay must be positive, got %s", value)
+	}
+	return d, nil
 }
 
 // Context represents a single environment context configuration
@@ -29,11 +256,64 @@ type PocketBaseConfig struct {
 	AuthRecord           map[string]interface{} `yaml:"auth_record"`            // Cached auth record
 	AutoRefresh          bool                   `yaml:"auto_refresh"`           // Refresh token proactively when nearing expiry
 	AutoRefreshThreshold string                 `yaml:"auto_refresh_threshold"` // Duration string (e.g. "15m"); empty => default
+
+	// CollectionDefaults maps a collection name to list options applied when listing
+	// it without the corresponding flags.
+	CollectionDefaults map[string]CollectionDefaults `yaml:"collection_defaults,omitempty"`
+}
+
+// CollectionDefaults holds default list options for one collection. Empty values
+// are not applied.
+type CollectionDefaults struct {
+	Filter string   `yaml:"filter,omitempty"`
+	Sort   string   `yaml:"sort,omitempty"`
+	Fields []string `yaml:"fields,omitempty"`
+}
+
+// IsEmpty reports whether no default is set.
+func (d CollectionDefaults) IsEmpty() bool {
+	return d.Filter == "" && d.Sort == "" && len(d.Fields) == 0
+}
+
+// FilterPresets holds named filter expressions per collection
+// (collection -> preset name -> filter). It is stored in the context directory
+// as filters.yaml rather than in context.yaml.
+type FilterPresets map[string]map[string]string
+
+// Get returns the filter saved under name for collection.
+func (p FilterPresets) Get(collection, name string) (string, bool) {
+	filter, exists := p[collection][name]
+	return filter, exists
+}
+
+// Set saves filter under name for collection, replacing any existing preset.
+func (p FilterPresets) Set(collection, name, filter string) {
+	if p[collection] == nil {
+		p[collection] = make(map[string]string)
+	}
+	p[collection][name] = filter
+}
+
+// Delete removes a preset, reporting whether it existed. A collection left with
+// no presets is removed entirely.
+func (p FilterPresets) Delete(collection, name string) bool {
+	if _, exists := p[collection][name]; !exists {
+		return false
+	}
+	delete(p[collection], name)
+	if len(p[collection]) == 0 {
+		delete(p, collection)
+	}
+	return true
 }
 
 // DefaultAutoRefreshThreshold is used when AutoRefresh is enabled but no threshold is set.
 const DefaultAutoRefreshThreshold = 15 * time.Minute
 
+// GlobalAutoRefreshThreshold is how close to expiry a token gets before the global
+// auto_refresh setting refreshes it, for contexts without their own auto_refresh.
+const GlobalAutoRefreshThreshold = 5 * time.Minute
+
 // GetAutoRefreshThreshold returns the parsed auto-refresh threshold, falling back to
 // DefaultAutoRefreshThreshold on empty or invalid values.
 func (p *PocketBaseConfig) GetAutoRefreshThreshold() time.Duration {
@@ -47,13 +327,44 @@ func (p *PocketBaseConfig) GetAutoRefreshThreshold() time.Duration {
 	return d
 }
 
+// Color modes accepted by --color
+const (
+	ColorModeAuto   = "auto"
+	ColorModeAlways = "always"
+	ColorModeNever  = "never"
+)
+
 // Output format constants
 const (
-	OutputFormatJSON  = "json"
-	OutputFormatYAML  = "yaml"
-	OutputFormatTable = "table"
+	OutputFormatJSON   = "json"
+	OutputFormatYAML   = "yaml"
+	OutputFormatTable  = "table"
+	OutputFormatHTML   = "html"
+	OutputFormatCSV    = "csv"
+	OutputFormatKeys   = "keys"
+	OutputFormatID     = "id"
+	OutputFormatNDJSON = "ndjson"
 )
 
+// OutputFormats lists every output format the CLI knows. Individual commands may
+// support only some of them.
+var OutputFormats = []string{
+	OutputFormatJSON, OutputFormatYAML, OutputFormatTable, OutputFormatHTML,
+	OutputFormatCSV, OutputFormatKeys, OutputFormatID, OutputFormatNDJSON,
+}
+
+// ValidateOutputFormat checks that format is one of OutputFormats. The match is
+// exact, as in the commands' format switches; the root command lower-cases
+// --output and output_format before they get here.
+func ValidateOutputFormat(format string) error {
+	for _, known := range OutputFormats {
+		if format == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format: %s (use %s)", format, strings.Join(OutputFormats, "|"))
+}
+
 // PocketBase auth collection constants. Any collection name is allowed; these are
 // just the common ones for v0.23+ (superuser auth lives in the _superusers collection).
 const (
@@ -62,7 +373,8 @@ const (
 )
 
 // ValidateAuthCollection validates a PocketBase auth collection name
-// Note: This is permissive to allow any collection name since PocketBase supports custom auth collections
+// Note: This is permissive to allow any collection name since PocketBase supports custom auth collections.
+// System collections such as _superusers start with an underscore, so a leading underscore is allowed.
 func ValidateAuthCollection(collection string) error {
 	if collection == "" {
 		return fmt.Errorf("auth collection cannot be empty")
@@ -73,6 +385,15 @@ func ValidateAuthCollection(collection string) error {
 		return fmt.Errorf("auth collection name must be between 1 and 50 characters")
 	}
 
+	for i, char := range collection {
+		if !((char >= 'a' && char <= 'z') ||
+			(char >= 'A' && char <= 'Z') ||
+			(char >= '0' && char <= '9' && i > 0) ||
+			char == '_') {
+			return fmt.Errorf("invalid auth collection '%s': use letters, digits, and underscores, not starting with a digit", collection)
+		}
+	}
+
 	return nil
 }
 
@@ -23,6 +23,22 @@ type AuthRequest struct {
 	Password string `json:"password"`
 }
 
+// OTPRequest represents a one-time password request
+type OTPRequest struct {
+	Email string `json:"email"`
+}
+
+// OTPResponse represents the response from request-otp
+type OTPResponse struct {
+	OTPID string `json:"otpId"`
+}
+
+// OTPAuthRequest represents an OTP authentication request
+type OTPAuthRequest struct {
+	OTPID    string `json:"otpId"`
+	Password string `json:"password"`
+}
+
 // Authenticate performs authentication against a specific collection
 func (c *Client) Authenticate(collection, identity, password string) (*AuthResponse, error) {
 	// Validate collection
@@ -69,6 +85,70 @@ func (c *Client) Authenticate(collection, identity, password string) (*AuthRespo
 	return &authResp, nil
 }
 
+// RequestOTP asks PocketBase to email a one-time password to the given address and
+// returns the otpId needed to complete authentication with AuthenticateWithOTP.
+func (c *Client) RequestOTP(collection, email string) (string, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return "", fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if email == "" {
+		return "", fmt.Errorf("email is required")
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/request-otp", collection)
+
+	utils.PrintDebug(fmt.Sprintf("Requesting OTP from collection: %s", collection))
+
+	resp, err := c.makeRequest("POST", endpoint, OTPRequest{Email: email})
+	if err != nil {
+		return "", fmt.Errorf("failed to request OTP: %w", err)
+	}
+
+	var otpResp OTPResponse
+	if err := json.Unmarshal(resp.Body(), &otpResp); err != nil {
+		return "", fmt.Errorf("failed to parse OTP response: %w", err)
+	}
+	if otpResp.OTPID == "" {
+		return "", fmt.Errorf("OTP response did not include an otpId (is OTP enabled for '%s'?)", collection)
+	}
+
+	return otpResp.OTPID, nil
+}
+
+// AuthenticateWithOTP exchanges an otpId and the emailed code for an auth token
+func (c *Client) AuthenticateWithOTP(collection, otpID, code string) (*AuthResponse, error) {
+	if err := config.ValidateAuthCollection(collection); err != nil {
+		return nil, fmt.Errorf("invalid auth collection: %w", err)
+	}
+	if otpID == "" {
+		return nil, fmt.Errorf("otpId is required")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("OTP code is required")
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/auth-with-otp", collection)
+
+	utils.PrintDebug(fmt.Sprintf("Authenticating with OTP against collection: %s", collection))
+
+	resp, err := c.makeRequest("POST", endpoint, OTPAuthRequest{OTPID: otpID, Password: code})
+	if err != nil {
+		return nil, fmt.Errorf("OTP authentication failed: %w", err)
+	}
+
+	var authResp AuthResponse
+	if err := json.Unmarshal(resp.Body(), &authResp); err != nil {
+		return nil, fmt.Errorf("failed to parse authentication response: %w", err)
+	}
+
+	c.SetAuthToken(authResp.Token)
+	c.authRecord = authResp.Record
+
+	utils.PrintDebug("OTP authentication successful")
+
+	return &authResp, nil
+}
+
 // RefreshAuth refreshes the current authentication token
 func (c *Client) RefreshAuth(collection string) (*AuthResponse, error) {
 	if !c.IsAuthenticated() {
@@ -98,6 +178,64 @@ func (c *Client) RefreshAuth(collection string) (*AuthResponse, error) {
 	return &authResp, nil
 }
 
+// ValidateAuth asks the server whether the current token is still accepted, which can
+// differ from the locally stored expiry (e.g. a token revoked server-side). It returns
+// false with a nil error when the server rejects the token, and an error only when the
+// check itself could not be completed. The refreshed token the server returns is discarded.
+func (c *Client) ValidateAuth(collection string) (bool, error) {
+	if !c.IsAuthenticated() {
+		return false, nil
+	}
+
+	endpoint := fmt.Sprintf("collections/%s/auth-refresh", collection)
+
+	utils.PrintDebug("Validating authentication token with server")
+
+	if _, err := c.makeRequest("POST", endpoint, nil); err != nil {
+		if pbErr, ok := err.(*PocketBaseError); ok && (pbErr.StatusCode == 401 || pbErr.StatusCode == 403) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to validate authentication: %w", err)
+	}
+
+	return true, nil
+}
+
+// EnableAutoReauth makes the client refresh its token before a request once it is
+// within config.GlobalAutoRefreshThreshold of expiring, so it cannot expire partway
+// through a long operation. PocketBase only refreshes tokens that are still valid,
+// so this must happen before expiry rather than after a 401. The refreshed token is
+// written back to ctx and persisted via cm.
+func (c *Client) EnableAutoReauth(ctx *config.Context, cm *config.Manager) {
+	collection := ctx.PocketBase.AuthCollection
+	if collection == "" {
+		collection = config.AuthCollectionUsers
+	}
+
+	c.reauth = func() error {
+		if ctx.PocketBase.AuthExpires == nil {
+			return nil
+		}
+		remaining := time.Until(*ctx.PocketBase.AuthExpires)
+		if remaining <= 0 || remaining > config.GlobalAutoRefreshThreshold {
+			return nil
+		}
+
+		authResp, err := c.RefreshAuth(collection)
+		if err != nil {
+			return err
+		}
+		if err := UpdateAuthContextFromResponse(ctx, authResp); err != nil {
+			return err
+		}
+		if err := cm.SaveContext(ctx); err != nil {
+			return fmt.Errorf("failed to persist refreshed token: %w", err)
+		}
+		utils.PrintDebug(fmt.Sprintf("Auth token refreshed %.0fs before expiry and saved", remaining.Seconds()))
+		return nil
+	}
+}
+
 // UpdateAuthContextFromResponse updates a context with authentication data
 func UpdateAuthContextFromResponse(ctx *config.Context, authResp *AuthResponse) error {
 	if authResp == nil {
@@ -141,10 +279,12 @@ func UpdateAuthContextFromResponse(ctx *config.Context, authResp *AuthResponse)
 	return nil
 }
 
-// EnsureFreshAuth proactively refreshes the auth token when AutoRefresh is enabled and
-// the token is within the configured threshold of expiring. It is a no-op when auto-refresh
-// is disabled, when there is no token, when the token has already expired, or when the token
-// is not yet close to expiry. On successful refresh the context is persisted via cm.
+// EnsureFreshAuth proactively refreshes the auth token when it is within the refresh
+// threshold of expiring. A context with its own AutoRefresh uses its configured threshold;
+// otherwise the global auto_refresh setting (on by default) applies with
+// config.GlobalAutoRefreshThreshold. It is a no-op when auto-refresh is disabled, when there
+// is no token, when the token has already expired, or when the token is not yet close to
+// expiry. On successful refresh the context is persisted via cm.
 //
 // A refresh failure is non-fatal: we warn and return nil so the caller can proceed with the
 // existing (still valid) token and let any genuine auth failure surface from the next request.
@@ -152,8 +292,12 @@ func EnsureFreshAuth(ctx *config.Context, cm *config.Manager) error {
 	if ctx == nil || cm == nil {
 		return nil
 	}
+	threshold := ctx.PocketBase.GetAutoRefreshThreshold()
 	if !ctx.PocketBase.AutoRefresh {
-		return nil
+		if !config.Global.AutoRefreshEnabled() {
+			return nil
+		}
+		threshold = config.GlobalAutoRefreshThreshold
 	}
 	if ctx.PocketBase.AuthToken == "" {
 		return nil
@@ -167,7 +311,6 @@ func EnsureFreshAuth(ctx *config.Context, cm *config.Manager) error {
 	if remaining <= 0 {
 		return nil
 	}
-	threshold := ctx.PocketBase.GetAutoRefreshThreshold()
 	if remaining > threshold {
 		return nil
 	}
@@ -214,11 +357,32 @@ func IsAuthValid(ctx *config.Context) bool {
 
 	// --- START: CORRECTED LOGIC ---
 	// Check if the current time is before the token's expiration time.
-	// The buffer has been removed as it caused issues with short-lived tokens.
-	return time.Now().Before(*ctx.PocketBase.AuthExpires)
+	// No buffer is applied by default as it caused issues with short-lived tokens;
+	// users on skewed clocks can opt into one via auth_expiry_buffer_seconds.
+	return time.Now().Before(ctx.PocketBase.AuthExpires.Add(-authExpiryBuffer()))
 	// --- END: CORRECTED LOGIC ---
 }
 
+// authExpiryBuffer returns the configured safety margin before token expiry.
+func authExpiryBuffer() time.Duration {
+	if config.Global.AuthExpiryBufferSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(config.Global.AuthExpiryBufferSeconds) * time.Second
+}
+
+// RequireSuperuser returns an error when the context is authenticated against a
+// collection other than _superusers. Endpoints such as backups only accept
+// superuser tokens, and a regular user token fails with an unhelpful 403.
+func RequireSuperuser(ctx *config.Context) error {
+	collection := ctx.PocketBase.AuthCollection
+	if collection == "" || collection == config.AuthCollectionSuperusers {
+		return nil
+	}
+	return fmt.Errorf("this command requires superuser auth, but context '%s' is authenticated against '%s'. Run 'pb auth --collection %s'",
+		ctx.Name, collection, config.AuthCollectionSuperusers)
+}
+
 // GetCollectionDisplayName returns a human-readable name for auth collections
 func GetCollectionDisplayName(collection string) string {
 	switch collection {
@@ -0,0 +1,127 @@
+package pocketbase_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+)
+
+// TestIsAuthValidExpiryBuffer checks that auth_expiry_buffer_seconds shifts the
+// expiry check, and that the default of 0 trusts the stored expiry exactly.
+func TestIsAuthValidExpiryBuffer(t *testing.T) {
+	original := config.Global.AuthExpiryBufferSeconds
+	defer func() { config.Global.AuthExpiryBufferSeconds = original }()
+
+	expires := time.Now().Add(30 * time.Second)
+	ctx := &config.Context{PocketBase: config.PocketBaseConfig{AuthToken: "token", AuthExpires: &expires}}
+
+	t.Run("No buffer", func(t *testing.T) {
+		config.Global.AuthExpiryBufferSeconds = 0
+		assert.True(t, pocketbase.IsAuthValid(ctx))
+	})
+
+	t.Run("Buffer shorter than remaining time", func(t *testing.T) {
+		config.Global.AuthExpiryBufferSeconds = 10
+		assert.True(t, pocketbase.IsAuthValid(ctx))
+	})
+
+	t.Run("Buffer longer than remaining time", func(t *testing.T) {
+		config.Global.AuthExpiryBufferSeconds = 60
+		assert.False(t, pocketbase.IsAuthValid(ctx))
+	})
+
+	t.Run("Negative buffer is ignored", func(t *testing.T) {
+		config.Global.AuthExpiryBufferSeconds = -60
+		past := time.Now().Add(-time.Second)
+		expired := &config.Context{PocketBase: config.PocketBaseConfig{AuthToken: "token", AuthExpires: &past}}
+		assert.False(t, pocketbase.IsAuthValid(expired))
+	})
+}
+
+// TestSuperuserCollection checks that _superusers is accepted as an auth
+// collection and that RequireSuperuser rejects regular auth collections.
+func TestSuperuserCollection(t *testing.T) {
+	assert.NoError(t, config.ValidateAuthCollection(config.AuthCollectionSuperusers))
+	assert.NoError(t, config.ValidateAuthCollection("users"))
+	assert.Error(t, config.ValidateAuthCollection("1users"))
+	assert.Error(t, config.ValidateAuthCollection("users/../x"))
+	assert.Equal(t, "Superusers", pocketbase.GetCollectionDisplayName(config.AuthCollectionSuperusers))
+
+	ctx := &config.Context{Name: "prod", PocketBase: config.PocketBaseConfig{AuthCollection: config.AuthCollectionSuperusers}}
+	assert.NoError(t, pocketbase.RequireSuperuser(ctx))
+
+	ctx.PocketBase.AuthCollection = "users"
+	err := pocketbase.RequireSuperuser(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "pb auth --collection _superusers")
+}
+
+// TestEnsureFreshAuthGlobalDefault checks that, without a per-context setting, a
+// token within five minutes of expiry is refreshed and saved, and that the global
+// auto_refresh toggle turns this off.
+func TestEnsureFreshAuthGlobalDefault(t *testing.T) {
+	original := config.Global.AutoRefresh
+	defer func() { config.Global.AutoRefresh = original }()
+
+	refreshed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/api/collections/users/auth-refresh", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"token": refreshed, "record": map[string]interface{}{"id": "u1"}})
+	}))
+	defer server.Close()
+
+	manager, err := config.NewManagerWithBase(filepath.Join(t.TempDir(), "pb"))
+	require.NoError(t, err)
+
+	newContext := func(remaining time.Duration) *config.Context {
+		expires := time.Now().Add(remaining)
+		return &config.Context{Name: "dev", PocketBase: config.PocketBaseConfig{
+			URL: server.URL, AuthCollection: "users", AuthToken: "old", AuthExpires: &expires,
+		}}
+	}
+
+	t.Run("Refreshes near expiry", func(t *testing.T) {
+		config.Global.AutoRefresh = nil
+		ctx := newContext(2 * time.Minute)
+		require.NoError(t, pocketbase.EnsureFreshAuth(ctx, manager))
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, refreshed, ctx.PocketBase.AuthToken)
+
+		saved, err := manager.LoadContext("dev")
+		require.NoError(t, err)
+		assert.Equal(t, refreshed, saved.PocketBase.AuthToken)
+	})
+
+	t.Run("Leaves tokens with time to spare", func(t *testing.T) {
+		calls = 0
+		ctx := newContext(10 * time.Minute)
+		require.NoError(t, pocketbase.EnsureFreshAuth(ctx, manager))
+		assert.Equal(t, 0, calls)
+		assert.Equal(t, "old", ctx.PocketBase.AuthToken)
+	})
+
+	t.Run("Disabled globally", func(t *testing.T) {
+		calls = 0
+		disabled := false
+		config.Global.AutoRefresh = &disabled
+		ctx := newContext(2 * time.Minute)
+		require.NoError(t, pocketbase.EnsureFreshAuth(ctx, manager))
+		assert.Equal(t, 0, calls)
+	})
+}
@@ -1,23 +1,54 @@
 package pocketbase
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"pb-cli/internal/config"
 	"pb-cli/internal/utils"
+	"pb-cli/internal/version"
 )
 
 const (
-	userAgent = "pb-cli/0.1.0"
-	// apiTimeout bounds ordinary API calls so a dead server fails fast.
-	apiTimeout = 30 * time.Second
+	// maxRetryAfterWait caps how long a GET waits before a retry, both for the
+	// backoff and for a Retry-After header; longer requested waits are reported
+	// instead of slept through.
+	maxRetryAfterWait = 30 * time.Second
+	// healthPollInitialDelay and healthPollMaxDelay bound WaitHealthy's polling:
+	// it watches for the restart at the initial delay, then backs off while the
+	// server comes back.
+	healthPollInitialDelay = 500 * time.Millisecond
+	healthPollMaxDelay     = 10 * time.Second
+	// healthRestartGrace is how long WaitHealthy sees only healthy answers before
+	// assuming the restart fell between two polls. PocketBase starts a restore about
+	// a second after accepting it and reports canBackup false from then on, so a
+	// restart still to come would have shown by this point.
+	healthRestartGrace = 3 * time.Second
+	// partialDownloadSuffix marks an in-progress backup download that can be resumed.
+	partialDownloadSuffix = ".part"
+	// partialVersionSuffix names the file next to a backup .part file that holds the
+	// Last-Modified time of the backup it was downloaded from, sent as If-Range.
+	partialVersionSuffix = ".modified"
 )
 
 // Client represents a PocketBase HTTP client
@@ -26,23 +57,66 @@ type Client struct {
 	baseURL    string
 	authToken  string
 	authRecord map[string]interface{}
+
+	// contextName and authExpired describe the context the client was built from,
+	// so requireAuth can say which context needs 'pb auth'.
+	contextName string
+	authExpired bool
+
+	// reauth, when set, runs before each request and refreshes the token when it
+	// is about to expire (see EnableAutoReauth). reauthing guards against recursion.
+	reauth    func() error
+	reauthing bool
 }
 
+// IdempotencyKeyHeader carries the idempotency key of a create (see
+// CreateRecordWithKey).
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// ErrAuthRequired is returned, wrapped with the context and the fix, by calls that
+// need a token when the client has none or its context's token has expired.
+var ErrAuthRequired = errors.New("authentication required")
+
 // FileTokenResponse represents the response from /api/files/token
 type FileTokenResponse struct {
 	Token string `json:"token"`
 }
 
-// NewClient creates a new PocketBase client
+// New creates a PocketBase client for baseURL, rejecting URLs that are not valid
+// http(s) server URLs so a bad URL fails here rather than on the first request.
+func New(baseURL string) (*Client, error) {
+	if err := utils.ValidatePocketBaseURL(baseURL); err != nil {
+		return nil, err
+	}
+	return NewClient(baseURL), nil
+}
+
+// NewClient creates a new PocketBase client without validating baseURL. It is kept
+// for callers whose URL was validated when it was stored (e.g. in a context); use
+// New for URLs from anywhere else.
 func NewClient(baseURL string) *Client {
-	client := resty.New()
+	client := newRestyClient()
 
 	// Set common headers
 	client.SetHeader("Content-Type", "application/json")
-	client.SetHeader("User-Agent", userAgent)
 
-	// Set timeout
-	client.SetTimeout(apiTimeout)
+	// Bound ordinary API calls (--timeout / request_timeout; 0 disables it).
+	client.SetTimeout(config.Global.RequestTimeoutDuration())
+
+	// Retry transient failures of idempotent requests with exponential backoff.
+	client.SetRetryCount(config.Global.RetryCount())
+	client.SetRetryWaitTime(config.Global.RetryWait())
+	client.SetRetryMaxWaitTime(maxRetryAfterWait)
+	client.SetRetryAfter(retryAfterHeader)
+	client.AddRetryCondition(shouldRetry)
+	client.AddRetryHook(func(resp *resty.Response, err error) {
+		if err != nil {
+			utils.PrintDebug(fmt.Sprintf("Request failed (%v); retrying if attempts remain", err))
+		} else {
+			utils.PrintDebug(fmt.Sprintf("Received %d; retrying if attempts remain", resp.StatusCode()))
+		}
+	})
+	client.SetLogger(retryLogger{dump: log.New(os.Stderr, "", log.Ldate|log.Lmicroseconds)})
 
 	// Enable debug mode if configured
 	if config.Global.Debug {
@@ -55,13 +129,61 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
+// newRestyClient returns a resty client with the settings every request shares:
+// the User-Agent, the proxy, and TLS verification. Without --proxy, HTTP_PROXY,
+// HTTPS_PROXY, and NO_PROXY apply as for any Go program.
+func newRestyClient() *resty.Client {
+	client := resty.New()
+	client.SetHeader("User-Agent", version.UserAgent())
+	if config.Global.Proxy != "" {
+		client.SetProxy(config.Global.Proxy)
+	}
+	if tlsConfig := newTLSConfig(); tlsConfig != nil {
+		client.SetTLSClientConfig(tlsConfig)
+	}
+	return client
+}
+
+// newTLSConfig returns the TLS settings for --insecure and --cacert, or nil to
+// keep the defaults. The root command has already checked that the CA file loads.
+func newTLSConfig() *tls.Config {
+	if config.Global.Insecure {
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+	if config.Global.CACertFile != "" {
+		pool, err := LoadCertPool(config.Global.CACertFile)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("ignoring --cacert: %v", err))
+			return nil
+		}
+		return &tls.Config{RootCAs: pool}
+	}
+	return nil
+}
+
+// LoadCertPool returns the system root certificates plus the PEM certificates
+// in path, for trusting a self-signed or private CA.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
 // newTransferClient builds a resty client with no timeout for long-running
 // backup operations (create/restore/upload/download). These can far exceed the
-// apiTimeout on large databases, so they must not inherit the 30s API cap.
+// request timeout on large databases, so they must not inherit it.
 func (c *Client) newTransferClient() *resty.Client {
-	client := resty.New()
+	client := newRestyClient()
 	client.SetHeader("Content-Type", "application/json")
-	client.SetHeader("User-Agent", userAgent)
 	if c.authToken != "" {
 		client.SetAuthToken(c.authToken)
 	}
@@ -72,22 +194,57 @@ func (c *Client) newTransferClient() *resty.Client {
 	return client
 }
 
+// newProbeClient builds a resty client that sends each request exactly once, for
+// polling and timing the server: retries and Retry-After waits would stretch the
+// caller's timeout or measurement. Callers set the timeout per request.
+func (c *Client) newProbeClient() *resty.Client {
+	client := newRestyClient()
+	client.SetHeader("Content-Type", "application/json")
+	client.SetTimeout(config.Global.RequestTimeoutDuration())
+	// Superuser auth makes PocketBase include canBackup in the health data.
+	if c.authToken != "" {
+		client.SetAuthToken(c.authToken)
+	}
+	if config.Global.Debug {
+		client.SetDebug(true)
+	}
+	return client
+}
+
 // NewClientFromContext creates a PocketBase client from a context configuration
 func NewClientFromContext(ctx *config.Context) *Client {
 	client := NewClient(ctx.PocketBase.URL)
+	client.contextName = ctx.Name
 
 	// Set authentication if available
 	if ctx.PocketBase.AuthToken != "" {
 		client.SetAuthToken(ctx.PocketBase.AuthToken)
 		client.authRecord = ctx.PocketBase.AuthRecord
+		client.authExpired = !IsAuthValid(ctx)
 	}
 
 	return client
 }
 
+// BaseURL returns the PocketBase server URL requests are sent to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetBaseURL points the client at another PocketBase server. The URL is validated
+// like in New; on error the client keeps its current URL.
+func (c *Client) SetBaseURL(baseURL string) error {
+	if err := utils.ValidatePocketBaseURL(baseURL); err != nil {
+		return err
+	}
+	c.baseURL = baseURL
+	return nil
+}
+
 // SetAuthToken sets the authentication token for requests
 func (c *Client) SetAuthToken(token string) {
 	c.authToken = token
+	c.authExpired = false
 	c.httpClient.SetAuthToken(token)
 }
 
@@ -106,51 +263,155 @@ func (c *Client) IsAuthenticated() bool {
 	return c.authToken != ""
 }
 
+// requireAuth returns nil when the client has a token to send, and otherwise an
+// error wrapping ErrAuthRequired. For a client built from a context, the error
+// names the context and suggests 'pb auth'; a token that had already expired
+// counts as missing, since PocketBase will not refresh it.
+func (c *Client) requireAuth() error {
+	switch {
+	case c.authToken == "" && c.contextName == "":
+		return ErrAuthRequired
+	case c.authToken == "":
+		return fmt.Errorf("%w: context '%s' is not authenticated; run 'pb auth' to log in", ErrAuthRequired, c.contextName)
+	case c.authExpired:
+		return fmt.Errorf("%w: the token for context '%s' has expired; run 'pb auth' to re-authenticate", ErrAuthRequired, c.contextName)
+	}
+	return nil
+}
+
 // makeRequest performs an HTTP request on the default (timeout-bounded) client.
 func (c *Client) makeRequest(method, endpoint string, body interface{}) (*resty.Response, error) {
 	return c.doRequest(c.httpClient, method, endpoint, body)
 }
 
+// withQuery appends encoded query parameters to an API endpoint.
+func withQuery(endpoint string, params url.Values) string {
+	if len(params) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + params.Encode()
+}
+
 // doRequest performs an HTTP request on the given client with shared error handling.
 func (c *Client) doRequest(client *resty.Client, method, endpoint string, body interface{}) (*resty.Response, error) {
+	return c.doRequestWithHeaders(client, method, endpoint, body, nil)
+}
+
+// doRequestWithHeaders is doRequest with extra request headers.
+func (c *Client) doRequestWithHeaders(client *resty.Client, method, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
 	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
 
 	utils.PrintDebug(fmt.Sprintf("Making %s request to %s", method, url))
 
+	// During a long operation, renew the token while the server still accepts it.
+	if c.reauth != nil && !c.reauthing {
+		c.reauthing = true
+		reauthErr := c.reauth()
+		c.reauthing = false
+		if reauthErr != nil {
+			utils.PrintWarning(fmt.Sprintf("auto-reauth failed: %v (continuing with existing token)", reauthErr))
+			c.reauth = nil
+		} else if c.authToken != "" {
+			client.SetAuthToken(c.authToken)
+		}
+	}
+
+	resp, err := sendRequest(client, method, url, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Response status: %d", resp.StatusCode()))
+
+	// Handle HTTP errors
+	if resp.StatusCode() >= 400 {
+		return resp, NewPocketBaseError(resp)
+	}
+
+	return resp, nil
+}
+
+// sendRequest issues a single HTTP request on client.
+func sendRequest(client *resty.Client, method, url string, body interface{}, headers map[string]string) (*resty.Response, error) {
 	var resp *resty.Response
 	var err error
 
+	req := client.R().SetHeaders(headers)
 	switch method {
 	case "GET":
-		resp, err = client.R().Get(url)
+		resp, err = req.Get(url)
 	case "POST":
-		resp, err = client.R().SetBody(body).Post(url)
+		resp, err = req.SetBody(body).Post(url)
 	case "PATCH":
-		resp, err = client.R().SetBody(body).Patch(url)
+		resp, err = req.SetBody(body).Patch(url)
 	case "DELETE":
-		resp, err = client.R().Delete(url)
+		resp, err = req.Delete(url)
 	default:
 		return nil, fmt.Errorf("unsupported HTTP method: %s", method)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, newTransportError(url, err)
 	}
 
-	utils.PrintDebug(fmt.Sprintf("Response status: %d", resp.StatusCode()))
+	return resp, nil
+}
 
-	// Handle HTTP errors
-	if resp.StatusCode() >= 400 {
-		return resp, NewPocketBaseError(resp)
+// shouldRetry decides whether resty retries a request. Only GETs are retried, so
+// a create or update is never sent twice; CreateRecordWithKey retries its creates
+// itself, after checking they didn't land. GETs are retried after transient
+// network failures (see isTransientTransportError) and on 429, 502, 503, and 504,
+// unless the server asks to wait longer than maxRetryAfterWait.
+func shouldRetry(resp *resty.Response, err error) bool {
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+	if resp.Request.Method != resty.MethodGet {
+		return false
 	}
+	if err != nil {
+		return isTransientTransportError(err)
+	}
+	switch resp.StatusCode() {
+	case 429, 502, 503, 504:
+		return parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()) <= maxRetryAfterWait
+	}
+	return false
+}
 
-	return resp, nil
+// retryAfterHeader returns the wait a response's Retry-After header asks for; 0
+// makes resty use its exponential backoff instead.
+func retryAfterHeader(_ *resty.Client, resp *resty.Response) (time.Duration, error) {
+	return parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()), nil
+}
+
+// retryLogger keeps resty's per-attempt warnings and final errors, which the
+// command reports itself, out of stderr unless debugging. Request dumps from
+// --debug are written like resty's default logger.
+type retryLogger struct {
+	dump *log.Logger
+}
+
+func (retryLogger) Errorf(format string, v ...interface{}) {
+	utils.PrintDebug(fmt.Sprintf(format, v...))
+}
+
+func (retryLogger) Warnf(format string, v ...interface{}) {
+	utils.PrintDebug(fmt.Sprintf(format, v...))
+}
+
+func (l retryLogger) Debugf(format string, v ...interface{}) {
+	if len(v) == 0 {
+		l.dump.Print("DEBUG RESTY " + format)
+		return
+	}
+	l.dump.Printf("DEBUG RESTY "+format, v...)
 }
 
 // GetFileToken requests a file access token for protected file downloads
 func (c *Client) GetFileToken() (string, error) {
-	if !c.IsAuthenticated() {
-		return "", fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return "", err
 	}
 
 	utils.PrintDebug("Requesting file token for protected file access")
@@ -165,11 +426,11 @@ func (c *Client) GetFileToken() (string, error) {
 		return "", fmt.Errorf("failed to parse file token response: %w", err)
 	}
 
-	preview := tokenResp.Token
-	if len(preview) > 10 {
-		preview = preview[:10]
+	if tokenResp.Token == "" {
+		return "", fmt.Errorf("server returned an empty file token")
 	}
-	utils.PrintDebug(fmt.Sprintf("Received file token: %s...", preview))
+
+	utils.PrintDebug(fmt.Sprintf("Received file token: %s", utils.TruncateForLog(tokenResp.Token, 10)))
 
 	return tokenResp.Token, nil
 }
@@ -188,46 +449,128 @@ func (c *Client) GetHealth() error {
 	return nil
 }
 
+// WaitHealthy waits for a restart the server has been asked to perform (e.g. after
+// a backup restore) and for the server to come back. A healthy answer only counts
+// once the restart has been seen: a failed health check, or a restore reported in
+// progress (canBackup false). A quick restart can fall between two polls, so
+// healthy answers for healthRestartGrace also count. Each poll is a single
+// request without retries, bounded by what is left of timeout, so the timeout
+// holds. It returns how long the wait took.
+func (c *Client) WaitHealthy(timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	probe := c.newProbeClient()
+	delay := healthPollInitialDelay
+	restarted := false
+
+	for {
+		probe.SetTimeout(time.Until(deadline))
+		health, err := c.checkHealth(probe)
+		switch {
+		case err != nil:
+			restarted = true
+		case health.Data["canBackup"] == false:
+			// PocketBase reports canBackup false while a restore is running.
+			restarted = true
+		case restarted:
+			return time.Since(start), nil
+		case time.Since(start) >= healthRestartGrace:
+			utils.PrintDebug("No restart seen; assuming it happened between health checks")
+			return time.Since(start), nil
+		}
+
+		elapsed := time.Since(start)
+		if err != nil {
+			utils.PrintDebug(fmt.Sprintf("Health check failed after %s: %v", elapsed.Round(time.Second), err))
+		}
+		if time.Until(deadline) < delay {
+			if !restarted {
+				return elapsed, fmt.Errorf("server did not restart within %s", timeout)
+			}
+			if err == nil {
+				err = fmt.Errorf("restore still in progress")
+			}
+			return elapsed, fmt.Errorf("server not healthy after %s: %w", elapsed.Round(time.Second), err)
+		}
+		time.Sleep(delay)
+
+		// Watch for the restart at a steady pace, then back off once it's underway.
+		if restarted {
+			delay *= 2
+			if delay > healthPollMaxDelay {
+				delay = healthPollMaxDelay
+			}
+		}
+	}
+}
+
+// CheckHealth returns the full PocketBase health response. Errors are returned
+// unwrapped so callers can inspect a PocketBaseError's status code.
+func (c *Client) CheckHealth() (*HealthResponse, error) {
+	return c.checkHealth(c.httpClient)
+}
+
+// ProbeHealth is CheckHealth as a single request without retries, also returning
+// how long it took, so the time measures one round trip rather than any backoff.
+func (c *Client) ProbeHealth() (*HealthResponse, time.Duration, error) {
+	start := time.Now()
+	health, err := c.checkHealth(c.newProbeClient())
+	return health, time.Since(start), err
+}
+
+// checkHealth requests the health endpoint on the given client.
+func (c *Client) checkHealth(client *resty.Client) (*HealthResponse, error) {
+	resp, err := c.doRequest(client, "GET", "health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var health HealthResponse
+	if err := json.Unmarshal(resp.Body(), &health); err != nil {
+		return nil, fmt.Errorf("failed to parse health response: %w", err)
+	}
+	if health.Code == 0 {
+		health.Code = resp.StatusCode()
+	}
+
+	return &health, nil
+}
+
 // ListRecords retrieves records from a collection with pagination and filtering
 func (c *Client) ListRecords(collection string, options *ListOptions) (*RecordsList, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("collections/%s/records", collection)
-
 	// Add query parameters
-	req := c.httpClient.R()
+	params := url.Values{}
 	if options != nil {
 		if options.Page > 0 {
-			req.SetQueryParam("page", fmt.Sprintf("%d", options.Page))
+			params.Set("page", fmt.Sprintf("%d", options.Page))
 		}
 		if options.PerPage > 0 {
-			req.SetQueryParam("perPage", fmt.Sprintf("%d", options.PerPage))
+			params.Set("perPage", fmt.Sprintf("%d", options.PerPage))
 		}
 		if options.Filter != "" {
-			req.SetQueryParam("filter", options.Filter)
+			params.Set("filter", options.Filter)
 		}
 		if options.Sort != "" {
-			req.SetQueryParam("sort", options.Sort)
+			params.Set("sort", options.Sort)
 		}
 		if len(options.Fields) > 0 {
-			req.SetQueryParam("fields", strings.Join(options.Fields, ","))
+			params.Set("fields", strings.Join(options.Fields, ","))
 		}
 		if len(options.Expand) > 0 {
-			req.SetQueryParam("expand", strings.Join(options.Expand, ","))
+			params.Set("expand", strings.Join(options.Expand, ","))
 		}
 	}
 
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
-	resp, err := req.Get(url)
+	endpoint := withQuery(fmt.Sprintf("collections/%s/records", collection), params)
 
+	// Return PocketBase errors unwrapped so callers can inspect them.
+	resp, err := c.makeRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list records: %w", err)
-	}
-
-	if resp.StatusCode() >= 400 {
-		return nil, NewPocketBaseError(resp)
+		return nil, err
 	}
 
 	var result RecordsList
@@ -242,6 +585,31 @@ func (c *Client) ListRecords(collection string, options *ListOptions) (*RecordsL
 // options.Page/PerPage and walks pages using PocketBase's maximum page size (500),
 // returning a single RecordsList with all items collected.
 func (c *Client) ListAllRecords(collection string, options *ListOptions) (*RecordsList, error) {
+	var items []map[string]interface{}
+	totalItems := 0
+	err := c.EachRecordPage(collection, options, func(page *RecordsList) error {
+		items = append(items, page.Items...)
+		totalItems = page.TotalItems
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RecordsList{
+		Page:       1,
+		PerPage:    len(items),
+		TotalItems: totalItems,
+		TotalPages: 1,
+		Items:      items,
+	}, nil
+}
+
+// EachRecordPage walks every page of records matching options, calling fn with each
+// page as it is fetched so callers can process huge collections without holding them
+// in memory. Like ListAllRecords it ignores options.Page/PerPage. Iteration stops at
+// the first error from the server or from fn.
+func (c *Client) EachRecordPage(collection string, options *ListOptions, fn func(page *RecordsList) error) error {
 	// Copy so we can drive pagination without mutating the caller's options.
 	opts := ListOptions{}
 	if options != nil {
@@ -250,39 +618,32 @@ func (c *Client) ListAllRecords(collection string, options *ListOptions) (*Recor
 	opts.Page = 1
 	opts.PerPage = 500
 
-	var items []map[string]interface{}
-	totalItems := 0
+	fetched := 0
 	for {
 		page, err := c.ListRecords(collection, &opts)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		items = append(items, page.Items...)
-		totalItems = page.TotalItems
+		fetched += len(page.Items)
+		utils.PrintDebug(fmt.Sprintf("Fetched page %d/%d (%d records so far)", opts.Page, page.TotalPages, fetched))
 
-		utils.PrintDebug(fmt.Sprintf("Fetched page %d/%d (%d records so far)", opts.Page, page.TotalPages, len(items)))
+		if err := fn(page); err != nil {
+			return err
+		}
 
 		if opts.Page >= page.TotalPages {
-			break
+			return nil
 		}
 		opts.Page++
 	}
-
-	return &RecordsList{
-		Page:       1,
-		PerPage:    len(items),
-		TotalItems: totalItems,
-		TotalPages: 1,
-		Items:      items,
-	}, nil
 }
 
 // GetCollections lists all collections defined on the instance. Requires superuser auth.
 // perPage is set high so instances with many collections aren't silently truncated.
 func (c *Client) GetCollections() ([]Collection, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.makeRequest("GET", "collections?perPage=500", nil)
@@ -303,8 +664,8 @@ func (c *Client) GetCollections() ([]Collection, error) {
 // GetCollectionSchema returns the definition (fields, rules) for a single collection.
 // Requires superuser auth.
 func (c *Client) GetCollectionSchema(collection string) (*Collection, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.makeRequest("GET", fmt.Sprintf("collections/%s", collection), nil)
@@ -322,29 +683,24 @@ func (c *Client) GetCollectionSchema(collection string) (*Collection, error) {
 
 // GetRecord retrieves a single record by ID with optional expand and fields filtering
 func (c *Client) GetRecord(collection, id string, expand []string, fields []string) (map[string]interface{}, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("collections/%s/records/%s", collection, id)
-
-	req := c.httpClient.R()
+	params := url.Values{}
 	if len(expand) > 0 {
-		req.SetQueryParam("expand", strings.Join(expand, ","))
+		params.Set("expand", strings.Join(expand, ","))
 	}
 	if len(fields) > 0 {
-		req.SetQueryParam("fields", strings.Join(fields, ","))
+		params.Set("fields", strings.Join(fields, ","))
 	}
 
-	url := fmt.Sprintf("%s/api/%s", c.baseURL, endpoint)
-	resp, err := req.Get(url)
+	endpoint := withQuery(fmt.Sprintf("collections/%s/records/%s", collection, id), params)
 
+	// Return PocketBase errors unwrapped so callers can inspect them.
+	resp, err := c.makeRequest("GET", endpoint, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get record: %w", err)
-	}
-
-	if resp.StatusCode() >= 400 {
-		return nil, NewPocketBaseError(resp)
+		return nil, err
 	}
 
 	var result map[string]interface{}
@@ -355,13 +711,14 @@ func (c *Client) GetRecord(collection, id string, expand []string, fields []stri
 	return result, nil
 }
 
-// CreateRecord creates a new record in a collection
-func (c *Client) CreateRecord(collection string, data map[string]interface{}) (map[string]interface{}, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+// CreateRecord creates a new record in a collection. Relations named in expand
+// are expanded in the returned record.
+func (c *Client) CreateRecord(collection string, data map[string]interface{}, expand []string) (map[string]interface{}, error) {
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("collections/%s/records", collection)
+	endpoint := withQuery(fmt.Sprintf("collections/%s/records", collection), expandParams(expand))
 
 	resp, err := c.makeRequest("POST", endpoint, data)
 	if err != nil {
@@ -376,13 +733,135 @@ func (c *Client) CreateRecord(collection string, data map[string]interface{}) (m
 	return result, nil
 }
 
-// UpdateRecord updates an existing record
-func (c *Client) UpdateRecord(collection, id string, data map[string]interface{}) (map[string]interface{}, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+// expandParams returns the expand query for relations to include in a
+// create or update response.
+func expandParams(expand []string) url.Values {
+	params := url.Values{}
+	if len(expand) > 0 {
+		params.Set("expand", strings.Join(expand, ","))
 	}
+	return params
+}
 
-	endpoint := fmt.Sprintf("collections/%s/records/%s", collection, id)
+// CreateRecordWithKey creates a record like CreateRecord, deduplicated by key.
+// PocketBase ignores the Idempotency-Key header (it is still sent, for proxies
+// that use it), so the record is instead created with an ID derived from key
+// (see IdempotentRecordID), or with the "id" already in data. A create that
+// fails transiently is retried only after looking that ID up: if the failed
+// attempt reached the server, the existing record is returned rather than
+// created again. The same lookup makes a rerun with the same key return the
+// record it created before. An empty key is a plain CreateRecord.
+func (c *Client) CreateRecordWithKey(collection string, data map[string]interface{}, key string, expand []string) (map[string]interface{}, error) {
+	if key == "" {
+		return c.CreateRecord(collection, data, expand)
+	}
+	if err := c.requireAuth(); err != nil {
+		return nil, err
+	}
+
+	id, _ := data["id"].(string)
+	if id == "" {
+		id = IdempotentRecordID(key)
+		keyed := make(map[string]interface{}, len(data)+1)
+		for field, value := range data {
+			keyed[field] = value
+		}
+		keyed["id"] = id
+		data = keyed
+	}
+
+	endpoint := withQuery(fmt.Sprintf("collections/%s/records", collection), expandParams(expand))
+	headers := map[string]string{IdempotencyKeyHeader: key}
+	wait := config.Global.RetryWait()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.doRequestWithHeaders(c.httpClient, "POST", endpoint, data, headers)
+		if err == nil {
+			var result map[string]interface{}
+			if err := json.Unmarshal(resp.Body(), &result); err != nil {
+				return nil, fmt.Errorf("failed to parse create response: %w", err)
+			}
+			return result, nil
+		}
+
+		// A 400 may be PocketBase rejecting the ID because this key already
+		// created the record; any other failure may have created it anyway.
+		var pbErr *PocketBaseError
+		alreadyExists := errors.As(err, &pbErr) && pbErr.StatusCode == 400
+		if !alreadyExists && !isTransientCreateError(err) {
+			return nil, fmt.Errorf("failed to create record: %w", err)
+		}
+		if existing, lookupErr := c.GetRecord(collection, id, expand, nil); lookupErr == nil {
+			utils.PrintDebug(fmt.Sprintf("Idempotency key '%s' already created record '%s'; not creating again", key, id))
+			return existing, nil
+		}
+		if alreadyExists || attempt >= config.Global.RetryCount() {
+			return nil, fmt.Errorf("failed to create record: %w", err)
+		}
+
+		utils.PrintDebug(fmt.Sprintf("Create with idempotency key '%s' failed (%v); retrying in %s", key, err, wait))
+		time.Sleep(wait)
+		wait = min(wait*2, maxRetryAfterWait)
+	}
+}
+
+// isAuthRefusal reports whether err means the server (or an expired local token)
+// refused to authenticate the request, as opposed to a failure to reach it.
+func isAuthRefusal(err error) bool {
+	if errors.Is(err, ErrAuthRequired) {
+		return true
+	}
+	var pbErr *PocketBaseError
+	return errors.As(err, &pbErr) && (pbErr.StatusCode == 401 || pbErr.StatusCode == 403)
+}
+
+// isTransientCreateError reports whether a failed create is worth retrying
+// once it is known not to have created the record: a timeout, a 429, or a
+// 502/503/504.
+func isTransientCreateError(err error) bool {
+	var pbErr *PocketBaseError
+	if errors.As(err, &pbErr) {
+		switch pbErr.StatusCode {
+		case 429, 502, 503, 504:
+			return true
+		}
+		return false
+	}
+	var transportErr *TransportError
+	return errors.As(err, &transportErr) && isTransientTransportError(transportErr)
+}
+
+// idAlphabet is the character set of PocketBase record IDs.
+const idAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// IdempotentRecordID derives a valid 15-character PocketBase record ID from an
+// idempotency key, so every create with the same key targets the same record.
+func IdempotentRecordID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	id := make([]byte, 15)
+	for i := range id {
+		id[i] = idAlphabet[int(sum[i])%len(idAlphabet)]
+	}
+	return string(id)
+}
+
+// NewIdempotencyKey returns a random key for CreateRecordWithKey.
+func NewIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// UpdateRecord updates an existing record. Relations named in expand are
+// expanded in the returned record.
+func (c *Client) UpdateRecord(collection, id string, data map[string]interface{}, expand []string) (map[string]interface{}, error) {
+	if err := c.requireAuth(); err != nil {
+		return nil, err
+	}
+
+	endpoint := withQuery(fmt.Sprintf("collections/%s/records/%s", collection, id), expandParams(expand))
 
 	resp, err := c.makeRequest("PATCH", endpoint, data)
 	if err != nil {
@@ -397,10 +876,122 @@ func (c *Client) UpdateRecord(collection, id string, data map[string]interface{}
 	return result, nil
 }
 
+// UploadRecordFiles updates a record with a multipart PATCH, sending each upload
+// as a file part. data holds any regular fields to change in the same request;
+// it is sent as PocketBase's @jsonPayload part so values keep their JSON types.
+func (c *Client) UploadRecordFiles(collection, id string, files []FileUpload, data map[string]interface{}) (map[string]interface{}, error) {
+	if err := c.requireAuth(); err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files to upload")
+	}
+
+	req := c.newTransferClient().R()
+	for _, upload := range files {
+		file, err := os.Open(upload.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", upload.Path, err)
+		}
+		defer file.Close()
+		req.SetFileReader(upload.Field, filepath.Base(upload.Path), file)
+		utils.PrintDebug(fmt.Sprintf("Attaching %s to field '%s'", upload.Path, upload.Field))
+	}
+	if len(data) > 0 {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode record data: %w", err)
+		}
+		// Not SetFormData: resty treats "@"-prefixed form keys as file paths.
+		req.SetMultipartField("@jsonPayload", "", "application/json", bytes.NewReader(payload))
+	}
+
+	url := fmt.Sprintf("%s/api/collections/%s/records/%s", c.baseURL, collection, id)
+	resp, err := req.Patch(url)
+	if err != nil {
+		return nil, newTransportError(url, err)
+	}
+	if resp.StatusCode() >= 400 {
+		return nil, NewPocketBaseError(resp)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(resp.Body(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse upload response: %w", err)
+	}
+
+	return result, nil
+}
+
+// FindRecordByField returns the first record whose field equals value, or nil if no
+// record matches. value must be a scalar (string, number, or bool).
+func (c *Client) FindRecordByField(collection, field string, value interface{}) (map[string]interface{}, error) {
+	if !utils.IsFilterField(field) {
+		return nil, fmt.Errorf("'%s' is not a field name that can be matched on", field)
+	}
+
+	// Strings are quoted the way the filter parser unescapes them (only the quote
+	// character); numbers and booleans are written bare.
+	var literal string
+	switch v := value.(type) {
+	case string:
+		quoted, err := utils.QuoteFilterValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot match on field '%s': %w", field, err)
+		}
+		literal = quoted
+	case float64:
+		literal = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		literal = strconv.FormatBool(v)
+	default:
+		return nil, fmt.Errorf("field '%s' must be a string, number, or boolean to match on", field)
+	}
+
+	result, err := c.ListRecords(collection, &ListOptions{
+		Page:    1,
+		PerPage: 1,
+		Filter:  fmt.Sprintf("%s=%s", field, literal),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return result.Items[0], nil
+}
+
+// UpsertRecord updates the record whose key field matches data[key], or creates a new
+// record when none matches. The returned bool reports whether a record was created.
+func (c *Client) UpsertRecord(collection, key string, data map[string]interface{}, expand []string) (map[string]interface{}, bool, error) {
+	value, ok := data[key]
+	if !ok {
+		return nil, false, fmt.Errorf("upsert key '%s' is missing from the record data", key)
+	}
+
+	existing, err := c.FindRecordByField(collection, key, value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		utils.PrintDebug(fmt.Sprintf("No record with %s=%v in '%s'; creating", key, value, collection))
+		record, err := c.CreateRecord(collection, data, expand)
+		return record, true, err
+	}
+
+	id := Record(existing).GetID()
+	utils.PrintDebug(fmt.Sprintf("Found record '%s' with %s=%v in '%s'; updating", id, key, value, collection))
+	record, err := c.UpdateRecord(collection, id, data, expand)
+	return record, false, err
+}
+
 // DeleteRecord deletes a record by ID
 func (c *Client) DeleteRecord(collection, id string) error {
-	if !c.IsAuthenticated() {
-		return fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return err
 	}
 
 	endpoint := fmt.Sprintf("collections/%s/records/%s", collection, id)
@@ -417,8 +1008,8 @@ func (c *Client) DeleteRecord(collection, id string) error {
 
 // ListBackups retrieves all available backups
 func (c *Client) ListBackups() (BackupsList, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	utils.PrintDebug("Listing backups from PocketBase")
@@ -439,8 +1030,8 @@ func (c *Client) ListBackups() (BackupsList, error) {
 
 // CreateBackup creates a new backup
 func (c *Client) CreateBackup(name string) (*Backup, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	utils.PrintDebug(fmt.Sprintf("Creating backup with name: %s", name))
@@ -502,8 +1093,8 @@ func (c *Client) CreateBackup(name string) (*Backup, error) {
 
 // GetBackup gets information about a specific backup
 func (c *Client) GetBackup(backupKey string) (*Backup, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	backups, err := c.ListBackups()
@@ -522,8 +1113,8 @@ func (c *Client) GetBackup(backupKey string) (*Backup, error) {
 
 // DownloadBackupWithProgress downloads a backup with progress reporting using file token authentication
 func (c *Client) DownloadBackupWithProgress(backupKey, outputPath string, progressCallback func(downloaded, total int64)) error {
-	if !c.IsAuthenticated() {
-		return fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return err
 	}
 
 	// Get backup info for size
@@ -546,30 +1137,80 @@ func (c *Client) DownloadBackupWithProgress(backupKey, outputPath string, progre
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Step 3: Create output file
-	outFile, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+	// Step 3: Data is written to a .part file and renamed into place once complete,
+	// so an interrupted download can resume from where it stopped. The version file
+	// records which backup the .part file holds, so a replaced backup restarts.
+	partPath := outputPath + partialDownloadSuffix
+	versionPath := partPath + partialVersionSuffix
+	var offset int64
+	var partVersion string
+	if stat, err := os.Stat(partPath); err == nil {
+		offset = stat.Size()
+		if data, err := os.ReadFile(versionPath); err == nil {
+			partVersion = strings.TrimSpace(string(data))
+		}
+		switch {
+		case partVersion == "":
+			utils.PrintDebug(fmt.Sprintf("Unknown backup version for %s; restarting", partPath))
+			offset = 0
+		case backup.Size > 0 && offset > backup.Size:
+			utils.PrintDebug(fmt.Sprintf("Partial file %s is larger than the backup; restarting", partPath))
+			offset = 0
+		}
 	}
-	defer outFile.Close()
 
 	// Step 4: Download using file token
 	url := fmt.Sprintf("%s/api/backups/%s", c.baseURL, backupKey)
 
-	utils.PrintDebug(fmt.Sprintf("Downloading from URL: %s", url))
+	utils.PrintDebug(fmt.Sprintf("Downloading from URL: %s", utils.RedactURL(url+"?token="+fileToken)))
 
 	// Create a fresh client without auth headers but with file token as query param.
 	// No timeout: large backups can take a long time to stream.
-	downloadClient := resty.New()
-	downloadClient.SetHeader("User-Agent", userAgent)
-
-	resp, err := downloadClient.R().
-		SetQueryParam("token", fileToken).
-		SetDoNotParseResponse(true).
-		Get(url)
+	downloadClient := newRestyClient()
+
+	fetch := func(offset int64) (*resty.Response, error) {
+		req := downloadClient.R().
+			SetQueryParam("token", fileToken).
+			SetDoNotParseResponse(true)
+		if offset > 0 {
+			// If the backup changed since partVersion, the server ignores the
+			// range and sends the whole new file.
+			req.SetHeader("Range", fmt.Sprintf("bytes=%d-", offset))
+			req.SetHeader("If-Range", partVersion)
+		}
+		resp, err := req.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download backup: %w", redactURLError(err))
+		}
+		return resp, nil
+	}
 
+	resp, err := fetch(offset)
 	if err != nil {
-		return fmt.Errorf("failed to download backup: %w", err)
+		return err
+	}
+
+	if resp.StatusCode() == 416 && offset > 0 {
+		resp.RawBody().Close()
+		// A partial file that already holds the whole backup leaves nothing to fetch.
+		if offset == backup.Size {
+			utils.PrintDebug("Partial file already complete")
+			if err := os.Rename(partPath, outputPath); err != nil {
+				return fmt.Errorf("failed to move completed download into place: %w", err)
+			}
+			os.Remove(versionPath)
+			return nil
+		}
+		// Otherwise the server ignored If-Range and the partial file belongs to a
+		// different backup than the one it has now.
+		utils.PrintWarning(fmt.Sprintf("partial download %s does not match the backup; restarting from the beginning", partPath))
+		if err := os.Remove(partPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove partial download: %w", err)
+		}
+		offset = 0
+		if resp, err = fetch(0); err != nil {
+			return err
+		}
 	}
 	defer resp.RawBody().Close()
 
@@ -577,12 +1218,36 @@ func (c *Client) DownloadBackupWithProgress(backupKey, outputPath string, progre
 		return fmt.Errorf("download failed with status %d: %s", resp.StatusCode(), resp.Status())
 	}
 
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch {
+	case offset > 0 && resp.StatusCode() == 206:
+		utils.PrintInfo(fmt.Sprintf("Resuming download from %s", utils.FormatBytes(offset)))
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	case offset > 0:
+		// A 200 is the whole file: either the backup changed since the partial
+		// download (If-Range did not match) or the server cannot resume.
+		utils.PrintWarning(fmt.Sprintf("cannot resume %s (backup changed or resuming unsupported); restarting from the beginning", partPath))
+		offset = 0
+	}
+	if offset == 0 {
+		if err := os.WriteFile(versionPath, []byte(backupVersion(resp, backup)), 0644); err != nil {
+			return fmt.Errorf("failed to record partial download version: %w", err)
+		}
+	}
+
+	outFile, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer outFile.Close()
+
 	// Step 5: Copy with progress
 	var written int64
 	if progressCallback != nil {
 		written, err = io.Copy(outFile, &progressReader{
 			reader:   resp.RawBody(),
 			total:    backup.Size,
+			read:     offset,
 			callback: progressCallback,
 		})
 	} else {
@@ -590,22 +1255,126 @@ func (c *Client) DownloadBackupWithProgress(backupKey, outputPath string, progre
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to save backup file: %w", err)
+		return fmt.Errorf("failed to save backup file (partial download kept at %s; rerun to resume): %w", partPath, redactURLError(err))
 	}
 
-	utils.PrintDebug(fmt.Sprintf("Downloaded %d bytes to: %s", written, outputPath))
+	total := offset + written
+	utils.PrintDebug(fmt.Sprintf("Downloaded %d bytes (%d total) to: %s", written, total, partPath))
 
-	if written == 0 {
+	if total == 0 {
 		return fmt.Errorf("downloaded file is empty")
 	}
+	if backup.Size > 0 && total != backup.Size {
+		return fmt.Errorf("downloaded size %d does not match backup size %d (partial download kept at %s; rerun to resume)",
+			total, backup.Size, partPath)
+	}
+
+	if err := outFile.Close(); err != nil {
+		return fmt.Errorf("failed to save backup file: %w", err)
+	}
+	if err := os.Rename(partPath, outputPath); err != nil {
+		return fmt.Errorf("failed to move completed download into place: %w", err)
+	}
+	os.Remove(versionPath)
+
+	return nil
+}
+
+// backupVersion returns the validator a resumed download sends as If-Range: the
+// Last-Modified time the server reported for the file, or else the backup's
+// modified time. It is empty when neither is known, and the download can't resume.
+func backupVersion(resp *resty.Response, backup *Backup) string {
+	if lastModified := resp.Header().Get("Last-Modified"); lastModified != "" {
+		return lastModified
+	}
+	if backup.Modified.IsZero() {
+		return ""
+	}
+	return backup.Modified.UTC().Format(http.TimeFormat)
+}
+
+// DownloadRecordFile downloads a file stored in a record's file field to
+// outputPath. thumb, when set, asks for an image thumbnail of that size (e.g.
+// "100x100"). With protected set and an authenticated client, a file token is
+// sent so files in protected fields can be fetched; if the server refuses the
+// token request (401/403), the download is tried without one.
+func (c *Client) DownloadRecordFile(collectionID, recordID, filename, thumb, outputPath string, protected bool, progressCallback func(downloaded, total int64)) error {
+	fileURL := fmt.Sprintf("%s/api/files/%s/%s/%s", c.baseURL,
+		url.PathEscape(collectionID), url.PathEscape(recordID), url.PathEscape(filename))
+
+	req := newRestyClient().R().SetDoNotParseResponse(true)
+	if thumb != "" {
+		req.SetQueryParam("thumb", thumb)
+	}
+	if protected && c.authToken != "" {
+		fileToken, err := c.GetFileToken()
+		switch {
+		case err == nil:
+			req.SetQueryParam("token", fileToken)
+		case isAuthRefusal(err):
+			// The file may still be public; let the download itself decide.
+			utils.PrintDebug(fmt.Sprintf("File token refused (%v); downloading without one", err))
+		default:
+			return fmt.Errorf("failed to get file access token: %w", err)
+		}
+	}
+
+	utils.PrintDebug(fmt.Sprintf("Downloading %s to %s", fileURL, outputPath))
+
+	resp, err := req.Get(fileURL)
+	if err != nil {
+		return fmt.Errorf("failed to download file: %w", redactURLError(err))
+	}
+	defer resp.RawBody().Close()
+
+	if resp.StatusCode() == 404 {
+		return fmt.Errorf("file '%s' not found on record '%s'", filename, recordID)
+	}
+	if resp.StatusCode() >= 400 {
+		return fmt.Errorf("download failed with status %d: %s", resp.StatusCode(), resp.Status())
+	}
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Write to a .part file and rename it into place, so a failed download
+	// never leaves a truncated file under the final name.
+	partPath := outputPath + partialDownloadSuffix
+	outFile, err := os.Create(partPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer outFile.Close()
+
+	var body io.Reader = resp.RawBody()
+	if progressCallback != nil {
+		body = &progressReader{
+			reader:   resp.RawBody(),
+			total:    resp.RawResponse.ContentLength,
+			callback: progressCallback,
+		}
+	}
+	if _, err := io.Copy(outFile, body); err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("failed to save file: %w", redactURLError(err))
+	}
+
+	if err := outFile.Close(); err != nil {
+		os.Remove(partPath)
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	if err := os.Rename(partPath, outputPath); err != nil {
+		return fmt.Errorf("failed to move completed download into place: %w", err)
+	}
 
 	return nil
 }
 
 // UploadBackup uploads a backup file using the correct PocketBase upload API
 func (c *Client) UploadBackup(filePath, backupName string, progressCallback func(uploaded, total int64)) (*Backup, error) {
-	if !c.IsAuthenticated() {
-		return nil, fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return nil, err
 	}
 
 	utils.PrintDebug(fmt.Sprintf("Uploading backup from %s", filePath))
@@ -626,11 +1395,30 @@ func (c *Client) UploadBackup(filePath, backupName string, progressCallback func
 
 	utils.PrintDebug(fmt.Sprintf("Upload URL: %s", url))
 
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	// Stream the form rather than letting resty buffer it, so progress follows the
+	// bytes actually sent and large backups aren't held in memory.
+	body, contentType, length, err := uploadBody(file, filepath.Base(filePath), fileInfo.Size(), progressCallback)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare upload: %w", err)
+	}
+
 	// Upload using authenticated client without the API timeout, since large
 	// backups can take a long time to transfer.
-	resp, err := c.newTransferClient().R().
-		SetFile("file", filePath). // Use "file" field name as per API docs
-		Post(url)                  // Use POST method as per API docs
+	client := c.newTransferClient()
+	client.SetPreRequestHook(func(_ *resty.Client, req *http.Request) error {
+		req.ContentLength = length
+		return nil
+	})
+	resp, err := client.R().
+		SetHeader("Content-Type", contentType).
+		SetBody(body).
+		Post(url) // Use POST method as per API docs
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload backup: %w", err)
@@ -681,8 +1469,8 @@ func (c *Client) UploadBackup(filePath, backupName string, progressCallback func
 
 // DeleteBackup deletes a backup
 func (c *Client) DeleteBackup(backupKey string) error {
-	if !c.IsAuthenticated() {
-		return fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return err
 	}
 
 	utils.PrintDebug(fmt.Sprintf("Deleting backup: %s", backupKey))
@@ -699,8 +1487,8 @@ func (c *Client) DeleteBackup(backupKey string) error {
 
 // RestoreBackup restores from a backup
 func (c *Client) RestoreBackup(backupKey string) error {
-	if !c.IsAuthenticated() {
-		return fmt.Errorf("authentication required")
+	if err := c.requireAuth(); err != nil {
+		return err
 	}
 
 	utils.PrintDebug(fmt.Sprintf("Restoring from backup: %s", backupKey))
@@ -717,19 +1505,58 @@ func (c *Client) RestoreBackup(backupKey string) error {
 	return nil
 }
 
-// progressReader wraps an io.Reader and calls a progress callback
+// redactURLError masks credentials in the URL that net/http embeds in request
+// errors (Get "https://...?token=..."), such as the backup download file token.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = utils.RedactURL(urlErr.URL)
+	}
+	return err
+}
+
+// uploadBody returns a multipart form with file as its "file" field (the name the
+// backup upload API expects), streamed as it is read. The callback, if any, is
+// called with the file bytes read so far against size; length is the exact size
+// of the whole body.
+func uploadBody(file io.Reader, fileName string, size int64, progressCallback func(uploaded, total int64)) (body io.Reader, contentType string, length int64, err error) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": fileName}))
+	header.Set("Content-Type", "application/zip")
+	if _, err := form.CreatePart(header); err != nil {
+		return nil, "", 0, err
+	}
+	head := bytes.Clone(buf.Bytes())
+	buf.Reset()
+	if err := form.Close(); err != nil {
+		return nil, "", 0, err
+	}
+	tail := bytes.Clone(buf.Bytes())
+
+	body = io.MultiReader(
+		bytes.NewReader(head),
+		&progressReader{reader: file, total: size, callback: progressCallback},
+		bytes.NewReader(tail),
+	)
+	return body, form.FormDataContentType(), int64(len(head)) + size + int64(len(tail)), nil
+}
+
+// progressReader wraps an io.Reader and calls a progress callback with the bytes
+// read so far
 type progressReader struct {
-	reader     io.Reader
-	total      int64
-	downloaded int64
-	callback   func(downloaded, total int64)
+	reader   io.Reader
+	total    int64
+	read     int64
+	callback func(read, total int64)
 }
 
 func (pr *progressReader) Read(p []byte) (int, error) {
 	n, err := pr.reader.Read(p)
-	pr.downloaded += int64(n)
+	pr.read += int64(n)
 	if pr.callback != nil {
-		pr.callback(pr.downloaded, pr.total)
+		pr.callback(pr.read, pr.total)
 	}
 	return n, err
 }
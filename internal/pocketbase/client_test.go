@@ -0,0 +1,826 @@
+package pocketbase_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+	"pb-cli/internal/pocketbase"
+)
+
+// TestDownloadBackupRedactsFileToken ensures the file token carried in the download
+// URL's query string never appears in debug logs or in the returned error.
+func TestDownloadBackupRedactsFileToken(t *testing.T) {
+	const fileToken = "secret-file-token"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups":
+			w.Write([]byte(`[{"key":"b.zip","size":100,"modified":"2024-01-01 10:00:00.000Z"}]`))
+		case "/api/files/token":
+			w.Write([]byte(`{"token":"` + fileToken + `"}`))
+		case "/api/backups/b.zip":
+			// Drop the connection so the HTTP client reports an error naming the URL.
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	oldDebug := config.Global.Debug
+	config.Global.Debug = true
+	defer func() { config.Global.Debug = oldDebug }()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	var err error
+	logged := captureStderr(t, func() {
+		err = client.DownloadBackupWithProgress("b.zip", filepath.Join(t.TempDir(), "b.zip"), nil)
+	})
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), fileToken)
+	assert.Contains(t, logged, "token=REDACTED")
+	assert.NotContains(t, logged, fileToken)
+}
+
+// captureStderr returns what fn writes to stderr.
+func captureStderr(t *testing.T, fn func()) string {
+	oldStderr := os.Stderr
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stderr = w
+
+	fn()
+
+	w.Close()
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+// TestNewValidatesBaseURL checks that New and SetBaseURL reject bad URLs up front.
+func TestNewValidatesBaseURL(t *testing.T) {
+	for _, bad := range []string{"", "localhost:8090", "ftp://example.com", "http://"} {
+		_, err := pocketbase.New(bad)
+		assert.Error(t, err, "New(%q)", bad)
+	}
+
+	client, err := pocketbase.New("http://localhost:8090")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8090", client.BaseURL())
+
+	assert.Error(t, client.SetBaseURL("not a url"))
+	assert.Equal(t, "http://localhost:8090", client.BaseURL())
+
+	require.NoError(t, client.SetBaseURL("https://pb.example.com"))
+	assert.Equal(t, "https://pb.example.com", client.BaseURL())
+}
+
+// TestServiceUnavailableRetryAfter checks that a GET answered with 503 waits out a
+// short Retry-After and retries once, while a long one is reported, not slept.
+func TestServiceUnavailableRetryAfter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("filter") == "long" {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"page":1,"perPage":30,"totalItems":0,"totalPages":0,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	_, err := client.ListRecords("posts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	_, err = client.ListRecords("posts", &pocketbase.ListOptions{Filter: "long"})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	var pbErr *pocketbase.PocketBaseError
+	require.ErrorAs(t, err, &pbErr)
+	assert.Equal(t, 120*time.Second, pbErr.RetryAfter)
+	assert.Contains(t, pbErr.GetFriendlyMessage(), "Retry in 120s")
+}
+
+// TestRetryTransientFailures checks that GETs are retried with backoff on 502 and
+// 429 until they succeed, while a POST or a 500 is sent only once.
+func TestRetryTransientFailures(t *testing.T) {
+	oldRetries, oldDelay := config.Global.Retries, config.Global.RetryDelay
+	defer func() { config.Global.Retries, config.Global.RetryDelay = oldRetries, oldDelay }()
+	retries := 2
+	config.Global.Retries = &retries
+	config.Global.RetryDelay = "1ms"
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Query().Get("filter") == "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case calls == 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case calls == 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"page":1,"perPage":30,"totalItems":0,"totalPages":0,"items":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	_, err := client.ListRecords("posts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = client.CreateRecord("posts", map[string]interface{}{"title": "once"}, nil)
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = client.ListRecords("posts", &pocketbase.ListOptions{Filter: "broken"})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+// TestProxy checks that --proxy routes API calls and the file-token backup
+// download, which uses its own client, through the proxy.
+func TestProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxied = append(proxied, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/backups":
+			w.Write([]byte(`[{"key":"b.zip","size":4,"modified":"2024-01-01 10:00:00.000Z"}]`))
+		case "/api/files/token":
+			w.Write([]byte(`{"token":"file-token"}`))
+		case "/api/backups/b.zip":
+			w.Write([]byte("data"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer proxy.Close()
+
+	oldProxy := config.Global.Proxy
+	config.Global.Proxy = proxy.URL
+	defer func() { config.Global.Proxy = oldProxy }()
+
+	// Nothing listens on the target; only the proxy can answer.
+	client := pocketbase.NewClient("http://pocketbase.invalid")
+	client.SetAuthToken("auth-token")
+
+	require.NoError(t, client.DownloadBackupWithProgress("b.zip", filepath.Join(t.TempDir(), "b.zip"), nil))
+	assert.Equal(t, []string{"/api/backups", "/api/files/token", "/api/backups/b.zip"}, proxied)
+}
+
+// TestTLSVerification checks that a self-signed server is rejected by default and
+// reachable with --cacert or --insecure, including the backup download client.
+func TestTLSVerification(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups":
+			w.Write([]byte(`[{"key":"b.zip","size":4,"modified":"2024-01-01 10:00:00.000Z"}]`))
+		case "/api/files/token":
+			w.Write([]byte(`{"token":"file-token"}`))
+		case "/api/backups/b.zip":
+			w.Write([]byte("data"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0o600))
+
+	oldInsecure, oldCA := config.Global.Insecure, config.Global.CACertFile
+	defer func() { config.Global.Insecure, config.Global.CACertFile = oldInsecure, oldCA }()
+
+	download := func() error {
+		client := pocketbase.NewClient(srv.URL)
+		client.SetAuthToken("auth-token")
+		return client.DownloadBackupWithProgress("b.zip", filepath.Join(t.TempDir(), "b.zip"), nil)
+	}
+
+	config.Global.Insecure, config.Global.CACertFile = false, ""
+	assert.Error(t, download())
+
+	config.Global.CACertFile = caFile
+	assert.NoError(t, download())
+
+	config.Global.Insecure, config.Global.CACertFile = true, ""
+	assert.NoError(t, download())
+
+	_, err := pocketbase.LoadCertPool(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("hello"), 0o600))
+	_, err = pocketbase.LoadCertPool(notPEM)
+	assert.Error(t, err)
+}
+
+// TestRequestTimeout checks that API calls are cut off after the configured
+// request timeout.
+func TestRequestTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	oldTimeout, oldRetries := config.Global.RequestTimeout, config.Global.Retries
+	defer func() { config.Global.RequestTimeout, config.Global.Retries = oldTimeout, oldRetries }()
+	noRetries := 0
+	config.Global.Retries = &noRetries
+
+	config.Global.RequestTimeout = "50ms"
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+	_, err := client.ListBackups()
+	var transportErr *pocketbase.TransportError
+	require.ErrorAs(t, err, &transportErr)
+	assert.Contains(t, transportErr.Error(), "timed out")
+
+	config.Global.RequestTimeout = "5s"
+	client = pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+	_, err = client.ListBackups()
+	assert.NoError(t, err)
+}
+
+// TestUploadBackupProgress checks that the backup is sent as the "file" form
+// field with an exact Content-Length and that progress reaches the file size.
+func TestUploadBackupProgress(t *testing.T) {
+	content := bytes.Repeat([]byte("backup"), 50000)
+	path := filepath.Join(t.TempDir(), "b.zip")
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/backups/upload", r.URL.Path)
+		assert.Greater(t, r.ContentLength, int64(len(content)))
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		received, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "b.zip", header.Filename)
+		assert.Equal(t, content, received)
+		w.Write([]byte(`{"key":"b.zip","size":300000}`))
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	var calls int
+	var last, total int64
+	backup, err := client.UploadBackup(path, "", func(uploaded, size int64) {
+		calls++
+		last, total = uploaded, size
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "b.zip", backup.Key)
+	assert.Greater(t, calls, 1)
+	assert.Equal(t, int64(len(content)), last)
+	assert.Equal(t, int64(len(content)), total)
+}
+
+// TestUploadRecordFiles checks that record uploads are a multipart PATCH with
+// one part per file and the other fields in @jsonPayload.
+func TestUploadRecordFiles(t *testing.T) {
+	dir := t.TempDir()
+	cover := filepath.Join(dir, "cover.png")
+	spec := filepath.Join(dir, "spec.pdf")
+	require.NoError(t, os.WriteFile(cover, []byte("png"), 0o644))
+	require.NoError(t, os.WriteFile(spec, []byte("pdf"), 0o644))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/collections/posts/records/abc", r.URL.Path)
+		if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+			http.Error(w, "bad form", http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "cover.png", r.MultipartForm.File["cover"][0].Filename)
+		assert.Equal(t, "spec.pdf", r.MultipartForm.File["attachments+"][0].Filename)
+		assert.JSONEq(t, `{"published":true}`, r.FormValue("@jsonPayload"))
+		w.Write([]byte(`{"id":"abc","cover":"cover_x1.png"}`))
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	record, err := client.UploadRecordFiles("posts", "abc", []pocketbase.FileUpload{
+		{Field: "cover", Path: cover},
+		{Field: "attachments+", Path: spec},
+	}, map[string]interface{}{"published": true})
+	require.NoError(t, err)
+	assert.Equal(t, "cover_x1.png", record["cover"])
+
+	_, err = client.UploadRecordFiles("posts", "abc", nil, nil)
+	assert.Error(t, err)
+}
+
+// TestDownloadRecordFile checks that record files are fetched from the files
+// API with a file token and the thumb size, and saved under the given path.
+func TestDownloadRecordFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/files/token":
+			w.Write([]byte(`{"token":"file-token"}`))
+		case "/api/files/col1/abc/cover_x1.png":
+			assert.Equal(t, "file-token", r.URL.Query().Get("token"))
+			assert.Equal(t, "100x100", r.URL.Query().Get("thumb"))
+			w.Write([]byte("png-bytes"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	out := filepath.Join(t.TempDir(), "sub", "cover.png")
+	var last int64
+	err := client.DownloadRecordFile("col1", "abc", "cover_x1.png", "100x100", out, true, func(downloaded, _ int64) {
+		last = downloaded
+	})
+	require.NoError(t, err)
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "png-bytes", string(data))
+	assert.Equal(t, int64(len("png-bytes")), last)
+
+	err = client.DownloadRecordFile("col1", "abc", "missing.png", "", out+"2", true, nil)
+	assert.ErrorContains(t, err, "not found")
+	assert.NoFileExists(t, out+"2.part")
+}
+
+// TestDownloadRecordFileToken checks that a file token is only requested for
+// protected fields, and that a refused token request falls back to a plain
+// download of a public file.
+func TestDownloadRecordFileToken(t *testing.T) {
+	tokenStatus := http.StatusOK
+	tokenRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/files/token":
+			tokenRequests++
+			if tokenStatus != http.StatusOK {
+				w.WriteHeader(tokenStatus)
+				fmt.Fprintf(w, `{"code":%d,"message":"Refused."}`, tokenStatus)
+				return
+			}
+			w.Write([]byte(`{"token":"file-token"}`))
+		case "/api/files/col1/abc/doc.pdf":
+			w.Write([]byte("pdf-bytes"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+	dir := t.TempDir()
+
+	require.NoError(t, client.DownloadRecordFile("col1", "abc", "doc.pdf", "", filepath.Join(dir, "public.pdf"), false, nil))
+	assert.Equal(t, 0, tokenRequests)
+
+	tokenStatus = http.StatusForbidden
+	require.NoError(t, client.DownloadRecordFile("col1", "abc", "doc.pdf", "", filepath.Join(dir, "refused.pdf"), true, nil))
+	assert.Equal(t, 1, tokenRequests)
+	assert.FileExists(t, filepath.Join(dir, "refused.pdf"))
+
+	tokenStatus = http.StatusInternalServerError
+	err := client.DownloadRecordFile("col1", "abc", "doc.pdf", "", filepath.Join(dir, "failed.pdf"), true, nil)
+	assert.ErrorContains(t, err, "file access token")
+}
+
+// TestWriteRecordExpand checks that creates and updates ask PocketBase to expand
+// relations in the returned record.
+func TestWriteRecordExpand(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.RawQuery)
+		w.Write([]byte(`{"id":"c1","post":"p1","expand":{"post":{"id":"p1"}}}`))
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	record, err := client.CreateRecord("comments", map[string]interface{}{"post": "p1"}, []string{"post", "author"})
+	require.NoError(t, err)
+	assert.Contains(t, record, "expand")
+	_, err = client.UpdateRecord("comments", "c1", map[string]interface{}{"post": "p1"}, []string{"post"})
+	require.NoError(t, err)
+	_, err = client.CreateRecord("comments", map[string]interface{}{"post": "p1"}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"POST expand=post%2Cauthor", "PATCH expand=post", "POST "}, queries)
+}
+
+// TestRequireAuthNamesContext checks that calls needing auth on a client built
+// from a context without a usable token say which context needs 'pb auth'.
+func TestRequireAuthNamesContext(t *testing.T) {
+	ctx := &config.Context{Name: "staging"}
+	ctx.PocketBase.URL = "http://127.0.0.1:1"
+
+	_, err := pocketbase.NewClientFromContext(ctx).ListRecords("posts", nil)
+	require.ErrorIs(t, err, pocketbase.ErrAuthRequired)
+	assert.Contains(t, err.Error(), "context 'staging' is not authenticated")
+	assert.Contains(t, err.Error(), "pb auth")
+
+	expired := time.Now().Add(-time.Hour)
+	ctx.PocketBase.AuthToken = "old-token"
+	ctx.PocketBase.AuthExpires = &expired
+	_, err = pocketbase.NewClientFromContext(ctx).GetRecord("posts", "abc", nil, nil)
+	require.ErrorIs(t, err, pocketbase.ErrAuthRequired)
+	assert.Contains(t, err.Error(), "token for context 'staging' has expired")
+
+	// A fresh token clears the expired state.
+	client := pocketbase.NewClientFromContext(ctx)
+	client.SetAuthToken("new-token")
+	_, err = client.ListRecords("posts", nil)
+	assert.NotErrorIs(t, err, pocketbase.ErrAuthRequired)
+
+	_, err = pocketbase.NewClient("http://127.0.0.1:1").ListRecords("posts", nil)
+	assert.EqualError(t, err, "authentication required")
+}
+
+// TestAutoReauthRefreshesBeforeExpiry checks that --auto-reauth renews a token
+// that is about to expire before sending the request, while it can still be
+// refreshed, and that an already expired token is not sent at all.
+func TestAutoReauthRefreshesBeforeExpiry(t *testing.T) {
+	freshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		if strings.HasSuffix(r.URL.Path, "/auth-refresh") {
+			fmt.Fprintf(w, `{"token":%q,"record":{"id":"user1"}}`, freshToken)
+			return
+		}
+		w.Write([]byte(`{"page":1,"perPage":30,"totalItems":0,"totalPages":0,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	cm, err := config.NewManagerWithBase(t.TempDir())
+	require.NoError(t, err)
+	expires := time.Now().Add(time.Minute)
+	ctx := &config.Context{Name: "prod"}
+	ctx.PocketBase.URL = srv.URL
+	ctx.PocketBase.AuthCollection = config.AuthCollectionUsers
+	ctx.PocketBase.AuthToken = "old-token"
+	ctx.PocketBase.AuthExpires = &expires
+
+	client := pocketbase.NewClientFromContext(ctx)
+	client.EnableAutoReauth(ctx, cm)
+	_, err = client.ListRecords("posts", nil)
+	require.NoError(t, err)
+	_, err = client.ListRecords("posts", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /api/collections/users/auth-refresh Bearer old-token",
+		"GET /api/collections/posts/records Bearer " + freshToken,
+		"GET /api/collections/posts/records Bearer " + freshToken,
+	}, requests)
+	assert.Equal(t, freshToken, ctx.PocketBase.AuthToken)
+	saved, err := cm.LoadContext("prod")
+	require.NoError(t, err)
+	assert.Equal(t, freshToken, saved.PocketBase.AuthToken)
+
+	requests = nil
+	expired := time.Now().Add(-time.Minute)
+	ctx.PocketBase.AuthToken = "old-token"
+	ctx.PocketBase.AuthExpires = &expired
+	client = pocketbase.NewClientFromContext(ctx)
+	client.EnableAutoReauth(ctx, cm)
+	_, err = client.ListRecords("posts", nil)
+	require.ErrorIs(t, err, pocketbase.ErrAuthRequired)
+	assert.Empty(t, requests)
+}
+
+// TestCreateRecordWithKey checks that a keyed create uses an ID derived from the
+// key, looks it up instead of creating a duplicate when a failed attempt landed
+// or the key was used before, and retries when the attempt didn't land.
+func TestCreateRecordWithKey(t *testing.T) {
+	oldRetries, oldDelay := config.Global.Retries, config.Global.RetryDelay
+	defer func() { config.Global.Retries, config.Global.RetryDelay = oldRetries, oldDelay }()
+	retries := 2
+	config.Global.Retries = &retries
+	config.Global.RetryDelay = "1ms"
+
+	stored := map[string]map[string]interface{}{}
+	var posts int
+	var failNext, landBeforeFailing bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			record, ok := stored[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":404,"message":"not found"}`))
+				return
+			}
+			json.NewEncoder(w).Encode(record)
+		case http.MethodPost:
+			posts++
+			assert.NotEmpty(t, r.Header.Get(pocketbase.IdempotencyKeyHeader))
+			var record map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&record))
+			id := record["id"].(string)
+			if _, exists := stored[id]; exists {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":400,"message":"Failed to create record.","data":{"id":{"code":"validation_invalid_id"}}}`))
+				return
+			}
+			if failNext {
+				failNext = false
+				if landBeforeFailing {
+					stored[id] = record
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			stored[id] = record
+			json.NewEncoder(w).Encode(record)
+		}
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+	data := map[string]interface{}{"title": "once"}
+
+	// The create lands but the response is a 503: no second POST.
+	failNext, landBeforeFailing = true, true
+	record, err := client.CreateRecordWithKey("posts", data, "import-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, pocketbase.IdempotentRecordID("import-1"), record["id"])
+	assert.Equal(t, 1, posts)
+	assert.NotContains(t, data, "id", "the caller's data isn't modified")
+
+	// A rerun with the same key returns the same record.
+	again, err := client.CreateRecordWithKey("posts", data, "import-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, record["id"], again["id"])
+	assert.Len(t, stored, 1)
+
+	// A 503 that didn't land is retried.
+	posts = 0
+	failNext, landBeforeFailing = true, false
+	_, err = client.CreateRecordWithKey("posts", data, "import-2", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, posts)
+	assert.Len(t, stored, 2)
+
+	id := pocketbase.IdempotentRecordID("import-1")
+	assert.Regexp(t, `^[a-z0-9]{15}$`, id)
+	assert.NotEqual(t, id, pocketbase.IdempotentRecordID("import-2"))
+
+	key, err := pocketbase.NewIdempotencyKey()
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+}
+
+// TestWaitHealthy checks that a healthy answer only ends the wait once the
+// restart has been seen, and that a server that never restarts times out.
+func TestWaitHealthy(t *testing.T) {
+	oldRetries := config.Global.Retries
+	defer func() { config.Global.Retries = oldRetries }()
+	retries := 3
+	config.Global.Retries = &retries
+
+	var statuses []int
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		w.WriteHeader(status)
+		w.Write([]byte(`{"code":200,"message":"API is healthy.","data":{"canBackup":true}}`))
+	}))
+	defer srv.Close()
+	client := pocketbase.NewClient(srv.URL)
+
+	// Still up before the restart, down once (not retried), then back.
+	statuses = []int{http.StatusOK, http.StatusServiceUnavailable, http.StatusOK}
+	_, err := client.WaitHealthy(10 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	statuses = nil
+	_, err = client.WaitHealthy(time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not restart")
+
+	// The whole down window fell between two polls: every answer is healthy, and
+	// the wait ends after the grace period rather than at the timeout.
+	calls = 0
+	waited, err := client.WaitHealthy(30 * time.Second)
+	require.NoError(t, err)
+	assert.Less(t, waited, 10*time.Second)
+	assert.Greater(t, calls, 1)
+}
+
+// TestDownloadBackupResume checks how a leftover .part file is handled by a server
+// that honors Range and If-Range like PocketBase's file serving does: resumed with
+// a 206 while the backup is unchanged, restarted from zero when the backup was
+// replaced (200), and kept as is when it already holds the whole backup (416). A
+// .part file without a recorded version is downloaded again from the start.
+func TestDownloadBackupResume(t *testing.T) {
+	modified := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	version := modified.Format(http.TimeFormat)
+
+	testCases := []struct {
+		name      string
+		part      string
+		version   string
+		content   string
+		modTime   time.Time
+		wantRange string
+	}{
+		{"Resumed with 206", "0123", version, "0123456789", modified, "bytes=4-"},
+		{"Backup replaced, restarted with 200", "0123", version, "abcdefghij", modified.Add(time.Hour), "bytes=4-"},
+		{"Already complete with 416", "0123456789", version, "0123456789", modified, "bytes=10-"},
+		{"Unknown version, restarted", "0123", "", "abcdefghij", modified, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ranges, ifRanges []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/backups":
+					fmt.Fprintf(w, `[{"key":"b.zip","size":10,"modified":%q}]`, pocketbase.FormatTime(tc.modTime))
+				case "/api/files/token":
+					w.Write([]byte(`{"token":"file-token"}`))
+				case "/api/backups/b.zip":
+					ranges = append(ranges, r.Header.Get("Range"))
+					ifRanges = append(ifRanges, r.Header.Get("If-Range"))
+					http.ServeContent(w, r, "b.zip", tc.modTime, strings.NewReader(tc.content))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer srv.Close()
+
+			client := pocketbase.NewClient(srv.URL)
+			client.SetAuthToken("auth-token")
+
+			outputPath := filepath.Join(t.TempDir(), "b.zip")
+			require.NoError(t, os.WriteFile(outputPath+".part", []byte(tc.part), 0o644))
+			if tc.version != "" {
+				require.NoError(t, os.WriteFile(outputPath+".part.modified", []byte(tc.version), 0o644))
+			}
+
+			captureStderr(t, func() {
+				require.NoError(t, client.DownloadBackupWithProgress("b.zip", outputPath, nil))
+			})
+
+			data, err := os.ReadFile(outputPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.content, string(data))
+			assert.NoFileExists(t, outputPath+".part")
+			assert.NoFileExists(t, outputPath+".part.modified")
+			require.Len(t, ranges, 1)
+			assert.Equal(t, tc.wantRange, ranges[0])
+			if tc.wantRange != "" {
+				assert.Equal(t, tc.version, ifRanges[0])
+			}
+		})
+	}
+}
+
+// TestDownloadBackupRecordsVersion checks that an interrupted download leaves the
+// backup's Last-Modified time next to the .part file for the next resume.
+func TestDownloadBackupRecordsVersion(t *testing.T) {
+	modified := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups":
+			fmt.Fprintf(w, `[{"key":"b.zip","size":10,"modified":%q}]`, pocketbase.FormatTime(modified))
+		case "/api/files/token":
+			w.Write([]byte(`{"token":"file-token"}`))
+		default:
+			// Only half of the backup arrives.
+			http.ServeContent(w, r, "b.zip", modified, strings.NewReader("01234"))
+		}
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	outputPath := filepath.Join(t.TempDir(), "b.zip")
+	err := client.DownloadBackupWithProgress("b.zip", outputPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rerun to resume")
+
+	version, err := os.ReadFile(outputPath + ".part.modified")
+	require.NoError(t, err)
+	assert.Equal(t, modified.Format(http.TimeFormat), string(version))
+}
+
+// TestProbeHealth checks that the health probe sends exactly one request even
+// when retries are configured, and reports its round-trip time.
+func TestProbeHealth(t *testing.T) {
+	oldRetries := config.Global.Retries
+	defer func() { config.Global.Retries = oldRetries }()
+	retries := 3
+	config.Global.Retries = &retries
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, latency, err := pocketbase.NewClient(srv.URL).ProbeHealth()
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, latency, time.Second)
+}
+
+// TestFindRecordByFieldFilter checks that the lookup behind --upsert-key writes
+// values the way PocketBase's filter parser reads them: strings quoted with only
+// the quote escaped (no JSON \u0026 or \\ sequences), numbers and booleans bare.
+func TestFindRecordByFieldFilter(t *testing.T) {
+	var filters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("filter"))
+		w.Write([]byte(`{"page":1,"perPage":1,"totalItems":0,"totalPages":0,"items":[]}`))
+	}))
+	defer srv.Close()
+
+	client := pocketbase.NewClient(srv.URL)
+	client.SetAuthToken("auth-token")
+
+	for _, value := range []interface{}{`A&B <x> C:\dir`, "it's", float64(12.5), true} {
+		_, err := client.FindRecordByField("posts", "slug", value)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{`slug='A&B <x> C:\dir'`, `slug='it\'s'`, "slug=12.5", "slug=true"}, filters)
+
+	_, err := client.FindRecordByField("posts", "slug || 1=1", "x")
+	assert.Error(t, err)
+	_, err = client.FindRecordByField("posts", "slug", `ends\`)
+	assert.Error(t, err)
+}
@@ -1,9 +1,17 @@
 package pocketbase
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -14,6 +22,9 @@ type PocketBaseError struct {
 	Message    string                 `json:"message"`
 	Data       map[string]interface{} `json:"data,omitempty"`
 	RawBody    string                 `json:"-"`
+	// RetryAfter is the wait the server asked for via a Retry-After header
+	// (typically on 503 while PocketBase restarts); zero when absent.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error implements the error interface
@@ -26,6 +37,7 @@ func NewPocketBaseError(resp *resty.Response) *PocketBaseError {
 	err := &PocketBaseError{
 		StatusCode: resp.StatusCode(),
 		RawBody:    string(resp.Body()),
+		RetryAfter: parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()),
 	}
 
 	// Try to parse error response JSON
@@ -47,6 +59,30 @@ func NewPocketBaseError(resp *resty.Response) *PocketBaseError {
 	return err
 }
 
+// parseRetryAfter converts a Retry-After header value, either delay-seconds or an
+// HTTP date, into a wait duration. Missing, malformed, or past values yield zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now).Round(time.Second)
+	}
+	return 0
+}
+
+// formatRetryAfter renders a Retry-After wait as whole seconds, e.g. "30s".
+func formatRetryAfter(d time.Duration) string {
+	return fmt.Sprintf("%ds", int(d.Round(time.Second)/time.Second))
+}
+
 // GetFriendlyMessage returns a user-friendly error message for PocketBase operations
 func (e *PocketBaseError) GetFriendlyMessage() string {
 	// Handle specific HTTP status codes first
@@ -68,6 +104,9 @@ func (e *PocketBaseError) GetFriendlyMessage() string {
 	case 500:
 		return "PocketBase server error. Please try again later or contact support"
 	case 503:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("PocketBase server is temporarily unavailable (it may be restarting). Retry in %s", formatRetryAfter(e.RetryAfter))
+		}
 		return "PocketBase service is temporarily unavailable. Please try again later"
 	}
 
@@ -305,3 +344,62 @@ func (e *PocketBaseError) GetSuggestion() string {
 
 	return "please check your input and try again"
 }
+
+// TransportError is a request that never got an HTTP response, classified into a
+// message that explains the likely cause (DNS, refused connection, TLS, timeout).
+type TransportError struct {
+	Message string
+	Err     error
+}
+
+// Error implements the error interface
+func (e *TransportError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying network error
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// newTransportError classifies a transport-level failure for the request to rawURL.
+func newTransportError(rawURL string, err error) *TransportError {
+	host := rawURL
+	base := rawURL
+	if u, parseErr := url.Parse(rawURL); parseErr == nil && u.Host != "" {
+		host = u.Hostname()
+		base = u.Scheme + "://" + u.Host
+	}
+
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+
+	msg := fmt.Sprintf("HTTP request failed: %v", err)
+	switch {
+	case errors.As(err, &dnsErr):
+		msg = fmt.Sprintf("could not resolve host '%s' — check the URL and your network/DNS", host)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		msg = fmt.Sprintf("connection refused — is PocketBase running at %s?", base)
+	case errors.As(err, &certErr):
+		msg = fmt.Sprintf("TLS certificate verification failed for '%s': %v", host, certErr.Err)
+	case errors.As(err, &recordErr):
+		msg = fmt.Sprintf("TLS handshake failed — %s does not appear to speak HTTPS (try http://)", base)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		msg = fmt.Sprintf("request to %s timed out — the server is slow or unreachable", base)
+	}
+
+	return &TransportError{Message: msg, Err: err}
+}
+
+// isTransientTransportError reports whether a transport failure is worth retrying:
+// timeouts and temporary DNS failures, but not refused connections or bad URLs.
+func isTransientTransportError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -3,6 +3,7 @@ package pocketbase
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"pb-cli/internal/utils"
@@ -17,6 +18,77 @@ type RecordsList struct {
 	Items      []map[string]interface{} `json:"items"`
 }
 
+// Records returns the list's items as Records. The conversion does not copy the
+// underlying maps.
+func (rl *RecordsList) Records() []Record {
+	records := make([]Record, len(rl.Items))
+	for i, item := range rl.Items {
+		records[i] = Record(item)
+	}
+	return records
+}
+
+// Record is a single record as returned by the API. It is a plain map so API
+// results convert to it for free, with typed accessors for common fields.
+type Record map[string]interface{}
+
+// GetID returns the record ID, or "" if the record has none (e.g. when --fields
+// excluded it).
+func (r Record) GetID() string {
+	return r.GetString("id")
+}
+
+// GetString returns field as a string, or "" when it is missing or not a string.
+func (r Record) GetString(field string) string {
+	value, _ := r[field].(string)
+	return value
+}
+
+// GetTime parses field as a PocketBase datetime. It reports false when the field
+// is missing, empty, or not a recognizable datetime.
+func (r Record) GetTime(field string) (time.Time, bool) {
+	value := r.GetString(field)
+	if value == "" {
+		return time.Time{}, false
+	}
+	t, err := parsePBTime(value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// DisplayNameFields lists every field GetDisplayName may read, besides id.
+var DisplayNameFields = []string{"name", "title", "display_name", "full_name", "first_name", "last_name", "email", "username"}
+
+// GetDisplayName returns a human-readable name for the record. Fields are tried
+// in this order: name, title, display_name, full_name, first_name + last_name,
+// email, username, and finally the ID as "ID: <id>". It is the single source of
+// display names for records and auth identities.
+func (r Record) GetDisplayName() string {
+	for _, field := range []string{"name", "title", "display_name", "full_name"} {
+		if name := r.GetString(field); name != "" {
+			return name
+		}
+	}
+
+	if fullName := strings.TrimSpace(r.GetString("first_name") + " " + r.GetString("last_name")); fullName != "" {
+		return fullName
+	}
+
+	for _, field := range []string{"email", "username"} {
+		if value := r.GetString(field); value != "" {
+			return value
+		}
+	}
+
+	if id := r.GetID(); id != "" {
+		return fmt.Sprintf("ID: %s", id)
+	}
+
+	return ""
+}
+
 // ListOptions represents options for listing records
 type ListOptions struct {
 	Page    int      `json:"page,omitempty"`
@@ -27,6 +99,13 @@ type ListOptions struct {
 	Expand  []string `json:"expand,omitempty"`
 }
 
+// FileUpload is a local file to send to a record's file field. Field may end
+// in "+" to append to a multiple-file field instead of replacing its files.
+type FileUpload struct {
+	Field string
+	Path  string
+}
+
 // Collection represents a PocketBase collection definition.
 // Field names match the PocketBase v0.23+ API (the old "schema" key is now "fields").
 type Collection struct {
@@ -50,6 +129,16 @@ type Field struct {
 	System      bool   `json:"system"`
 	Required    bool   `json:"required"`
 	Presentable bool   `json:"presentable"`
+	// Protected file fields need a file token to download.
+	Protected bool `json:"protected"`
+}
+
+// HealthResponse represents the /api/health response. Data.canBackup is only
+// reported to superusers.
+type HealthResponse struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
 }
 
 // Backup represents a PocketBase backup
@@ -75,6 +164,28 @@ func (pbt *PBTime) UnmarshalJSON(data []byte) error {
 		timeStr = timeStr[1 : len(timeStr)-1]
 	}
 
+	t, err := parsePBTime(timeStr)
+	if err != nil {
+		return err
+	}
+	pbt.Time = t
+	return nil
+}
+
+// ParseTime parses a datetime in any of the formats PocketBase uses, e.g.
+// "2024-01-02 15:04:05.000Z" or RFC 3339.
+func ParseTime(timeStr string) (time.Time, error) {
+	return parsePBTime(timeStr)
+}
+
+// FormatTime renders t in PocketBase's datetime format (UTC, millisecond
+// precision), as used in filter expressions and record timestamps.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.000Z")
+}
+
+// parsePBTime parses a datetime in any of the formats PocketBase uses.
+func parsePBTime(timeStr string) (time.Time, error) {
 	// Try multiple time formats that PocketBase might use
 	formats := []string{
 		"2006-01-02 15:04:05.999Z", // PocketBase format with space and microseconds
@@ -87,12 +198,11 @@ func (pbt *PBTime) UnmarshalJSON(data []byte) error {
 
 	for _, format := range formats {
 		if t, err := time.Parse(format, timeStr); err == nil {
-			pbt.Time = t
-			return nil
+			return t, nil
 		}
 	}
 
-	return fmt.Errorf("unable to parse time: %s", timeStr)
+	return time.Time{}, fmt.Errorf("unable to parse time: %s", timeStr)
 }
 
 // MarshalJSON implements custom JSON marshaling
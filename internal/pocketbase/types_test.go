@@ -0,0 +1,83 @@
+package pocketbase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/pocketbase"
+)
+
+func TestRecordAccessors(t *testing.T) {
+	record := pocketbase.Record{
+		"id":      "abc123",
+		"created": "2024-01-15 10:30:00.123Z",
+		"views":   float64(3),
+		"updated": "",
+	}
+
+	assert.Equal(t, "abc123", record.GetID())
+	assert.Equal(t, "", record.GetString("views"), "non-string values read as empty")
+
+	created, ok := record.GetTime("created")
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2024, 1, 15, 10, 30, 0, 123000000, time.UTC), created)
+
+	_, ok = record.GetTime("updated")
+	assert.False(t, ok)
+	_, ok = record.GetTime("missing")
+	assert.False(t, ok)
+}
+
+// TestRecordsListRecords checks that Records wraps the items without copying them.
+func TestRecordsListRecords(t *testing.T) {
+	list := &pocketbase.RecordsList{Items: []map[string]interface{}{{"id": "a"}, {"id": "b"}}}
+
+	records := list.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "b", records[1].GetID())
+
+	records[0]["title"] = "changed"
+	assert.Equal(t, "changed", list.Items[0]["title"])
+}
+
+// TestRecordGetDisplayName pins the field preference order, which collection and
+// auth output both rely on.
+func TestRecordGetDisplayName(t *testing.T) {
+	full := pocketbase.Record{
+		"id":           "abc123",
+		"name":         "Name",
+		"title":        "Title",
+		"display_name": "Display",
+		"full_name":    "Full",
+		"first_name":   "First",
+		"last_name":    "Last",
+		"email":        "a@example.com",
+		"username":     "user",
+	}
+	// Removing each winning field in turn must reveal the next one in order.
+	expected := []struct {
+		field string
+		want  string
+	}{
+		{"name", "Name"},
+		{"title", "Title"},
+		{"display_name", "Display"},
+		{"full_name", "Full"},
+		{"first_name", "First Last"},
+		{"last_name", "Last"},
+		{"email", "a@example.com"},
+		{"username", "user"},
+		{"id", "ID: abc123"},
+	}
+
+	for _, step := range expected {
+		assert.Equal(t, step.want, full.GetDisplayName(), "before removing %s", step.field)
+		delete(full, step.field)
+	}
+	assert.Equal(t, "", full.GetDisplayName())
+
+	assert.Equal(t, "First", pocketbase.Record{"first_name": "First", "last_name": ""}.GetDisplayName())
+	assert.Equal(t, "Name", pocketbase.Record{"name": "Name", "title": ""}.GetDisplayName())
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dayWeekUnit matches a number with a d (day) or w (week) unit. Neither letter is
+// a time.ParseDuration unit, so the match can't split a stdlib unit like "ms".
+var dayWeekUnit = regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`)
+
+// ParseDuration parses a duration like time.ParseDuration, and also accepts d
+// (24h) and w (7d) units, alone or combined with others: 30d, 2w, 1d12h, 1h30m.
+// Days are fixed 24-hour spans; daylight saving changes are not considered.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration cannot be empty")
+	}
+
+	var convErr error
+	expanded := dayWeekUnit.ReplaceAllStringFunc(value, func(part string) string {
+		match := dayWeekUnit.FindStringSubmatch(part)
+		n, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			convErr = err
+			return part
+		}
+		hours := n * 24
+		if match[2] == "w" {
+			hours *= 7
+		}
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+	})
+
+	d, err := time.ParseDuration(expanded)
+	if convErr != nil || err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 90s, 15m, 2h, 30d, 2w)", value)
+	}
+	return d, nil
+}
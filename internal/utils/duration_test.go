@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/utils"
+)
+
+func TestParseDuration(t *testing.T) {
+	valid := map[string]time.Duration{
+		"30d":   30 * 24 * time.Hour,
+		"2w":    14 * 24 * time.Hour,
+		"1h30m": 90 * time.Minute,
+		"1d12h": 36 * time.Hour,
+		"1w2d":  9 * 24 * time.Hour,
+		"1.5d":  36 * time.Hour,
+		"500ms": 500 * time.Millisecond,
+		" 7d ":  7 * 24 * time.Hour,
+		"-1d":   -24 * time.Hour,
+		"0":     0,
+	}
+	for input, want := range valid {
+		got, err := utils.ParseDuration(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "d", "30", "30x", "1dd", "2 weeks", "1y", "99999999w"} {
+		_, err := utils.ParseDuration(input)
+		assert.Error(t, err, input)
+	}
+}
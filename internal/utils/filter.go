@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filterFieldPattern matches the field names FilterFromValues accepts: plain and
+// dotted fields (author.name), modifiers (tags:length), and @ macros.
+var filterFieldPattern = regexp.MustCompile(`^[@A-Za-z_][A-Za-z0-9_.:@]*$`)
+
+// IsFilterField reports whether field can be placed in a filter expression as a
+// field name, as FilterFromValues requires.
+func IsFilterField(field string) bool {
+	return filterFieldPattern.MatchString(field)
+}
+
+// QuoteFilterValue returns value as a single-quoted PocketBase filter string. As
+// in the official SDKs, a quote inside the value is escaped as \' and nothing else
+// changes, so apostrophes, backslashes, "--", and ";" all stay literal. A trailing
+// backslash can't be represented (it would escape the closing quote) and is an
+// error.
+func QuoteFilterValue(value string) (string, error) {
+	if strings.HasSuffix(value, `\`) {
+		return "", fmt.Errorf("filter values cannot end with a backslash")
+	}
+	return "'" + strings.ReplaceAll(value, "'", `\'`) + "'", nil
+}
+
+// FilterFromValues builds a filter matching every "field=value" pair exactly, as
+// field='value' conditions joined with &&. Values are quoted with QuoteFilterValue.
+func FilterFromValues(pairs []string) (string, error) {
+	conditions := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		field, value, ok := strings.Cut(pair, "=")
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return "", fmt.Errorf("invalid filter value %q: expected field=value", pair)
+		}
+		if !IsFilterField(field) {
+			return "", fmt.Errorf("invalid filter value %q: %q is not a field name", pair, field)
+		}
+		quoted, err := QuoteFilterValue(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid filter value for %s: %w", field, err)
+		}
+		conditions = append(conditions, field+"="+quoted)
+	}
+	return strings.Join(conditions, " && "), nil
+}
+
+// whereConditionPattern splits a FilterFromConditions condition into its field,
+// operator, and value. Two-character operators come first so ">=" isn't read as ">".
+var whereConditionPattern = regexp.MustCompile(`^\s*([@A-Za-z_][A-Za-z0-9_.:@]*)\s*(!=|>=|<=|!~|=|>|<|~)(.*)$`)
+
+// filterNumberPattern matches the numbers FilterFromConditions leaves unquoted.
+var filterNumberPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// FilterFromConditions builds a filter from simple "field<op>value" conditions
+// joined with &&, such as "status=paid", "total>=10", or "title~draft". The
+// operators are =, !=, >, >=, <, <=, ~ (contains), and !~. Numbers, true, false,
+// and null are used as they are; any other value is quoted with QuoteFilterValue,
+// and a value wrapped in quotes ('true', "42") is always taken as a string.
+func FilterFromConditions(conditions []string) (string, error) {
+	parts := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		match := whereConditionPattern.FindStringSubmatch(condition)
+		if match == nil {
+			return "", fmt.Errorf("invalid condition %q: expected field, an operator (=, !=, >, >=, <, <=, ~, !~), and a value", condition)
+		}
+		literal, err := filterLiteral(strings.TrimSpace(match[3]))
+		if err != nil {
+			return "", fmt.Errorf("invalid condition %q: %w", condition, err)
+		}
+		parts = append(parts, match[1]+match[2]+literal)
+	}
+	return strings.Join(parts, " && "), nil
+}
+
+// filterLiteral renders value for FilterFromConditions: bare for numbers, true,
+// false, and null, otherwise quoted.
+func filterLiteral(value string) (string, error) {
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		return QuoteFilterValue(value[1 : len(value)-1])
+	}
+	switch value {
+	case "true", "false", "null":
+		return value, nil
+	}
+	if filterNumberPattern.MatchString(value) {
+		return value, nil
+	}
+	return QuoteFilterValue(value)
+}
+
+// CombineFilters joins the non-blank filters with &&, parenthesizing each when
+// there is more than one so their || operators keep their meaning.
+func CombineFilters(filters ...string) string {
+	var parts []string
+	for _, filter := range filters {
+		if filter = strings.TrimSpace(filter); filter != "" {
+			parts = append(parts, filter)
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	for i, part := range parts {
+		parts[i] = "(" + part + ")"
+	}
+	return strings.Join(parts, " && ")
+}
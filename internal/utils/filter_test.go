@@ -0,0 +1,76 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/utils"
+)
+
+func TestQuoteFilterValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", `'plain'`},
+		{"O'Brien", `'O\'Brien'`},
+		{`say "hi"`, `'say "hi"'`},
+		{`C:\temp\new`, `'C:\temp\new'`},
+		{`a\'b`, `'a\\'b'`},
+		{"x'; DROP --", `'x\'; DROP --'`},
+		{"", `''`},
+	}
+	for _, tt := range tests {
+		got, err := utils.QuoteFilterValue(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	_, err := utils.QuoteFilterValue(`ends with \`)
+	assert.Error(t, err)
+}
+
+func TestFilterFromValues(t *testing.T) {
+	filter, err := utils.FilterFromValues([]string{"name=O'Brien", "note=a -- b=c", "author.email=x@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, `name='O\'Brien' && note='a -- b=c' && author.email='x@example.com'`, filter)
+
+	for _, pair := range []string{"noequals", "=value", "name || 1=x", "a'b=c"} {
+		_, err := utils.FilterFromValues([]string{pair})
+		assert.Error(t, err, pair)
+	}
+}
+
+func TestCombineFilters(t *testing.T) {
+	assert.Equal(t, "", utils.CombineFilters("", "  "))
+	assert.Equal(t, "a=1", utils.CombineFilters("", "a=1"))
+	assert.Equal(t, "(a=1 || b=2) && (c='x')", utils.CombineFilters("a=1 || b=2", "", "c='x'"))
+}
+
+func TestFilterFromConditions(t *testing.T) {
+	tests := []struct {
+		conditions []string
+		want       string
+	}{
+		{[]string{"status=paid"}, `status='paid'`},
+		{[]string{"total>=10", "stock > 0"}, `total>=10 && stock>0`},
+		{[]string{"price<=-1.5e2", "views<1000"}, `price<=-1.5e2 && views<1000`},
+		{[]string{"title~O'Brien", "title!~draft"}, `title~'O\'Brien' && title!~'draft'`},
+		{[]string{"published=true", "deleted!=null"}, `published=true && deleted!=null`},
+		{[]string{"code='42'", `name="true"`}, `code='42' && name='true'`},
+		{[]string{"author.name=a -- b"}, `author.name='a -- b'`},
+		{[]string{"hex=0x1F", "n=NaN"}, `hex='0x1F' && n='NaN'`},
+		{[]string{"note="}, `note=''`},
+	}
+	for _, tt := range tests {
+		got, err := utils.FilterFromConditions(tt.conditions)
+		require.NoError(t, err, tt.conditions)
+		assert.Equal(t, tt.want, got, tt.conditions)
+	}
+
+	for _, condition := range []string{"status", "=paid", "a b=c", `path=C:\`} {
+		_, err := utils.FilterFromConditions([]string{condition})
+		assert.Error(t, err, condition)
+	}
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"pb-cli/internal/config"
+)
+
+// ItemError is one failure of a batch operation and the item it concerns, such
+// as a record ID or an array index.
+type ItemError struct {
+	Item string
+	Err  error
+}
+
+// MultiError collects the failures of a batch operation. Error returns the
+// one-line Message; Write renders every failure for the user.
+type MultiError struct {
+	// Message is returned by Error, e.g. "failed to delete 2 of 40 record(s)".
+	Message string
+	// Limit caps how many failures Write lists; 0 lists them all.
+	Limit  int
+	Errors []ItemError
+}
+
+// DefaultMultiErrorLimit is the number of failures batch commands list before
+// summarizing the rest.
+const DefaultMultiErrorLimit = 20
+
+// Add records err for item. A nil err is ignored.
+func (m *MultiError) Add(item string, err error) {
+	if err != nil {
+		m.Errors = append(m.Errors, ItemError{Item: item, Err: err})
+	}
+}
+
+// Len returns the number of failures collected.
+func (m *MultiError) Len() int {
+	return len(m.Errors)
+}
+
+// ErrorOrNil returns m when it holds failures and nil otherwise, so it can be
+// returned directly as a command's error.
+func (m *MultiError) ErrorOrNil() error {
+	if m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error returns Message, or a count of the failures when Message is unset.
+func (m *MultiError) Error() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	return fmt.Sprintf("%d error(s) occurred", m.Len())
+}
+
+// Unwrap returns the collected errors for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.Errors))
+	for i, itemErr := range m.Errors {
+		errs[i] = itemErr.Err
+	}
+	return errs
+}
+
+// MarshalJSON renders {"count": N, "errors": [{"item": ..., "error": ...}]},
+// listing every failure regardless of Limit.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	type itemJSON struct {
+		Item  string `json:"item"`
+		Error string `json:"error"`
+	}
+	items := make([]itemJSON, len(m.Errors))
+	for i, itemErr := range m.Errors {
+		items[i] = itemJSON{Item: itemErr.Item, Error: itemErr.Err.Error()}
+	}
+	return json.Marshal(struct {
+		Count  int        `json:"count"`
+		Errors []itemJSON `json:"errors"`
+	}{Count: m.Len(), Errors: items})
+}
+
+// Write renders the failures to w: as JSON for json and ndjson output, otherwise
+// as an indented list of "item: error" lines ending in "... and N more" past Limit.
+func (m *MultiError) Write(w io.Writer, format string) error {
+	if m.Len() == 0 {
+		return nil
+	}
+	if format == config.OutputFormatJSON || format == config.OutputFormatNDJSON {
+		return WriteJSONLine(w, m)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Failed (%d):\n", m.Len())
+	shown := m.Errors
+	if m.Limit > 0 && len(shown) > m.Limit {
+		shown = shown[:m.Limit]
+	}
+	for _, itemErr := range shown {
+		fmt.Fprintf(&b, "  %s: %v\n", itemErr.Item, itemErr.Err)
+	}
+	if hidden := m.Len() - len(shown); hidden > 0 {
+		fmt.Fprintf(&b, "  ... and %d more\n", hidden)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,42 @@
+package utils_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/config"
+	"pb-cli/internal/utils"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestMultiError(t *testing.T) {
+	var empty utils.MultiError
+	assert.NoError(t, empty.ErrorOrNil())
+
+	m := &utils.MultiError{Limit: 2}
+	m.Add("a", errNotFound)
+	m.Add("b", errors.New("forbidden"))
+	m.Add("skipped", nil)
+	m.Add("c", errors.New("invalid"))
+	require.Equal(t, 3, m.Len())
+
+	err := m.ErrorOrNil()
+	require.Error(t, err)
+	assert.Equal(t, "3 error(s) occurred", err.Error())
+	assert.ErrorIs(t, err, errNotFound)
+	m.Message = "failed to delete 3 of 10 record(s)"
+	assert.Equal(t, m.Message, err.Error())
+
+	var text bytes.Buffer
+	require.NoError(t, m.Write(&text, config.OutputFormatTable))
+	assert.Equal(t, "Failed (3):\n  a: not found\n  b: forbidden\n  ... and 1 more\n", text.String())
+
+	// JSON lists every failure regardless of the limit.
+	var jsonOut bytes.Buffer
+	require.NoError(t, m.Write(&jsonOut, config.OutputFormatJSON))
+	assert.JSONEq(t, `{"count":3,"errors":[{"item":"a","error":"not found"},{"item":"b","error":"forbidden"},{"item":"c","error":"invalid"}]}`, jsonOut.String())
+}
@@ -1,100 +1,347 @@
 package utils
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"html"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
+	"golang.org/x/term"
 	"gopkg.in/yaml.v3"
 	"pb-cli/internal/config"
 )
 
-// OutputData formats and prints data according to the specified format
+// dataOutput is where OutputData writes when set, such as the file named by the
+// global --output-file flag; nil means stdout.
+var dataOutput io.Writer
+
+// SetDataOutput redirects OutputData to w; nil restores stdout.
+func SetDataOutput(w io.Writer) {
+	dataOutput = w
+}
+
+// DataOutput returns the writer OutputData writes to, for commands that render
+// their data themselves.
+func DataOutput() io.Writer {
+	if dataOutput == nil {
+		return os.Stdout
+	}
+	return dataOutput
+}
+
+// CreateOutputFile creates (or truncates) the file at path for writing output,
+// creating its parent directories as needed.
+func CreateOutputFile(path string) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return f, nil
+}
+
+// OutputData formats and prints data to stdout (or the --output-file) according to
+// the specified format
 func OutputData(data interface{}, format string) error {
+	return OutputDataTo(DataOutput(), data, format)
+}
+
+// OutputDataTo formats and writes data to w according to the specified format
+func OutputDataTo(w io.Writer, data interface{}, format string) error {
 	switch strings.ToLower(format) {
 	case config.OutputFormatJSON, "":
-		return outputJSON(data)
+		return outputJSON(w, data)
 	case config.OutputFormatYAML:
-		return outputYAML(data)
+		return outputYAML(w, data)
 	case config.OutputFormatTable:
-		return outputTable(data)
+		return outputTable(w, data)
+	case config.OutputFormatHTML:
+		return outputHTML(w, data)
+	case config.OutputFormatCSV:
+		return OutputCSV(w, data, CSVOptions{})
+	case config.OutputFormatID:
+		return outputID(w, data)
+	case config.OutputFormatNDJSON:
+		return outputNDJSON(w, data)
 	default:
 		return fmt.Errorf("unsupported output format: %s", format)
 	}
 }
 
-// outputJSON prints data in JSON format
-func outputJSON(data interface{}) error {
+// outputJSON prints data in JSON format, syntax-highlighted when useJSONColor
+// allows it
+func outputJSON(w io.Writer, data interface{}) error {
 	output, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
 	}
-	fmt.Println(string(output))
+	if useJSONColor(w) {
+		_, err = fmt.Fprintln(w, colorizeJSON(output))
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(output))
+	return err
+}
+
+// useJSONColor reports whether JSON written to w should be highlighted: only
+// for stdout itself (never --output-file), when --color-json is on and UseColor
+// allows colors there.
+func useJSONColor(w io.Writer) bool {
+	return config.Global.ColorJSON && w == io.Writer(os.Stdout) && UseColor(os.Stdout)
+}
+
+// colorizeJSON highlights keys, strings, numbers, and literals (true, false,
+// null) in src, which must be valid JSON such as json.MarshalIndent produces.
+// Stripping the escape codes gives back src unchanged.
+func colorizeJSON(src []byte) string {
+	keyColor := colored(color.FgBlue)
+	stringColor := colored(color.FgGreen)
+	numberColor := colored(color.FgYellow)
+	literalColor := colored(color.FgMagenta)
+
+	var b strings.Builder
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+			next := end
+			for next < len(src) && (src[next] == ' ' || src[next] == '\n' || src[next] == '\t' || src[next] == '\r') {
+				next++
+			}
+			if next < len(src) && src[next] == ':' {
+				b.WriteString(keyColor(string(src[i:end])))
+			} else {
+				b.WriteString(stringColor(string(src[i:end])))
+			}
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(src) && strings.IndexByte("0123456789.eE+-", src[end]) >= 0 {
+				end++
+			}
+			b.WriteString(numberColor(string(src[i:end])))
+			i = end
+		case c >= 'a' && c <= 'z':
+			end := i + 1
+			for end < len(src) && src[end] >= 'a' && src[end] <= 'z' {
+				end++
+			}
+			b.WriteString(literalColor(string(src[i:end])))
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// outputID prints only the "id" of a single record, for capturing in scripts.
+// It applies to single-record results; lists are rejected.
+func outputID(w io.Writer, data interface{}) error {
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("--output id applies only to a single record (get, create, update); use --output keys to list IDs")
+	}
+	id, ok := record["id"].(string)
+	if !ok || id == "" {
+		return fmt.Errorf("record has no id to print")
+	}
+	_, err := fmt.Fprintln(w, id)
+	return err
+}
+
+// outputNDJSON prints data as newline-delimited JSON: one compact line per item
+// of a list, or a single line for anything else.
+func outputNDJSON(w io.Writer, data interface{}) error {
+	var items []interface{}
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		for _, item := range v {
+			items = append(items, item)
+		}
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{data}
+	}
+
+	for _, item := range items {
+		if err := WriteJSONLine(w, item); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// WriteJSONLine writes value as a single line of compact JSON.
+func WriteJSONLine(w io.Writer, value interface{}) error {
+	line, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", line)
+	return err
+}
+
 // outputYAML prints data in YAML format
-func outputYAML(data interface{}) error {
+func outputYAML(w io.Writer, data interface{}) error {
 	output, err := yaml.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal YAML: %w", err)
 	}
-	fmt.Print(string(output))
-	return nil
+	_, err = fmt.Fprint(w, string(output))
+	return err
 }
 
 // outputTable prints data in table format
-func outputTable(data interface{}) error {
+func outputTable(w io.Writer, data interface{}) error {
+	return OutputTable(w, data, TableOptions{})
+}
+
+// TableOptions controls table rendering. The zero value keeps every cell on a
+// single line, truncating long values.
+type TableOptions struct {
+	MaxCellLines int // wrap long values over up to this many lines; 0 or 1 truncates
+}
+
+// OutputTable writes data as a table with the given options.
+func OutputTable(w io.Writer, data interface{}, opts TableOptions) error {
 	switch v := data.(type) {
 	case []map[string]interface{}:
-		return outputMapSliceTable(v)
+		return outputMapSliceTable(w, v, opts)
 	case map[string]interface{}:
-		return outputMapTable(v)
+		return outputMapTable(w, v)
 	default:
 		// Fallback to JSON for complex types
-		return outputJSON(data)
+		return outputJSON(w, data)
 	}
 }
 
-// outputMapSliceTable outputs a slice of maps as a table
-func outputMapSliceTable(data []map[string]interface{}) error {
-	if len(data) == 0 {
-		fmt.Println("No data found.")
-		return nil
-	}
-
-	// Extract headers from first item, prioritizing common fields
+// tableHeaders returns the column order for a slice of maps: common fields first,
+// then the remaining keys in alphabetical order, so the order is stable between
+// runs. Keys are collected from every item, so rows with differing fields (e.g.
+// records from several collections) don't lose columns.
+func tableHeaders(data []map[string]interface{}) []string {
 	var headers []string
 	commonFields := []string{"id", "name", "title", "email", "created", "updated"}
 
+	keys := make(map[string]bool)
+	for _, item := range data {
+		for key := range item {
+			keys[key] = true
+		}
+	}
+
 	// Add common fields first if they exist
-	firstItem := data[0]
 	for _, field := range commonFields {
-		if _, exists := firstItem[field]; exists {
+		if keys[field] {
 			headers = append(headers, field)
+			delete(keys, field)
 		}
 	}
 
 	// Add remaining fields
-	for key := range firstItem {
+	remaining := make([]string, 0, len(keys))
+	for key := range keys {
+		remaining = append(remaining, key)
+	}
+	sort.Strings(remaining)
+
+	return append(headers, remaining...)
+}
+
+// fieldOrder returns the keys of a single map with priority fields first.
+func fieldOrder(data map[string]interface{}) []string {
+	priorityFields := []string{"id", "name", "title", "email", "description", "type", "created", "updated"}
+	var orderedKeys []string
+
+	// Add priority fields first
+	for _, field := range priorityFields {
+		if _, exists := data[field]; exists {
+			orderedKeys = append(orderedKeys, field)
+		}
+	}
+
+	// Add remaining fields in alphabetical order, so the output is stable
+	var remaining []string
+	for key := range data {
 		found := false
-		for _, existing := range headers {
+		for _, existing := range orderedKeys {
 			if existing == key {
 				found = true
 				break
 			}
 		}
 		if !found {
-			headers = append(headers, key)
+			remaining = append(remaining, key)
 		}
 	}
+	sort.Strings(remaining)
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader(headers)
+	return append(orderedKeys, remaining...)
+}
+
+// columnAlignments right-aligns columns whose values are all numbers (ignoring
+// nulls) so sizes and counts line up; everything else stays left-aligned.
+func columnAlignments(data []map[string]interface{}, headers []string) []int {
+	alignments := make([]int, len(headers))
+	for i, header := range headers {
+		alignments[i] = tablewriter.ALIGN_LEFT
+
+		numeric := false
+		for _, item := range data {
+			value := item[header]
+			if value == nil {
+				continue
+			}
+			if !isNumber(value) {
+				numeric = false
+				break
+			}
+			numeric = true
+		}
+		if numeric {
+			alignments[i] = tablewriter.ALIGN_RIGHT
+		}
+	}
+	return alignments
+}
+
+// isNumber reports whether value is a numeric type
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case float64, float32, int, int64, int32, json.Number:
+		return true
+	}
+	return false
+}
+
+// newTableWriter builds a borderless, left-aligned table writing to w
+func newTableWriter(w io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
 	table.SetBorder(false)
 	table.SetHeaderLine(false)
 	table.SetRowSeparator("")
@@ -102,13 +349,76 @@ func outputMapSliceTable(data []map[string]interface{}) error {
 	table.SetColumnSeparator("  ")
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	return table
+}
 
-	// Add rows
-	for _, item := range data {
-		var row []string
-		for _, header := range headers {
-			value := formatTableValue(item[header])
-			row = append(row, value)
+// outputMapSliceTable outputs a slice of maps as a table
+func outputMapSliceTable(w io.Writer, data []map[string]interface{}, opts TableOptions) error {
+	if len(data) == 0 {
+		fmt.Fprintln(w, "No data found.")
+		return nil
+	}
+
+	headers := tableHeaders(data)
+
+	table := newTableWriter(w)
+	table.SetHeader(headers)
+	table.SetColumnAlignment(columnAlignments(data, headers))
+
+	// Cells are wrapped by hand rather than with tablewriter's auto-wrap, which
+	// has no line limit; tablewriter still renders the embedded newlines.
+	fitCell := func(value string, limit int) string {
+		if opts.MaxCellLines > 1 {
+			return wrapRunes(value, limit, opts.MaxCellLines)
+		}
+		return truncateRunes(value, limit)
+	}
+
+	width := terminalWidth(w)
+	if width == 0 {
+		// Width unknown (not a terminal): fixed per-value width.
+		if opts.MaxCellLines > 1 {
+			table.SetAutoWrapText(false)
+		}
+		for _, item := range data {
+			var row []string
+			for _, header := range headers {
+				value := formatTableValue(item[header])
+				if opts.MaxCellLines > 1 {
+					value = fitCell(formatTableValueWidth(item[header], 0), defaultTableValueWidth)
+				}
+				row = append(row, value)
+			}
+			table.Append(row)
+		}
+		table.Render()
+		return nil
+	}
+
+	// On a terminal, size each column to its content and shrink the widest ones
+	// until the table fits the window.
+	rows := make([][]string, len(data))
+	widths := make([]int, len(headers))
+	floors := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+		floors[i] = max(widths[i], minTableColumnWidth)
+	}
+	for r, item := range data {
+		rows[r] = make([]string, len(headers))
+		for i, header := range headers {
+			rows[r][i] = formatTableValueWidth(item[header], 0)
+			if n := utf8.RuneCountInString(rows[r][i]); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	limits := fitColumnWidths(widths, floors, width-tableColumnOverhead*len(headers)-1)
+	table.SetAutoWrapText(false)
+	for _, row := range rows {
+		for i := range row {
+			row[i] = fitCell(row[i], limits[i])
 		}
 		table.Append(row)
 	}
@@ -117,45 +427,138 @@ func outputMapSliceTable(data []map[string]interface{}) error {
 	return nil
 }
 
-// outputMapTable outputs a single map as a vertical table
-func outputMapTable(data map[string]interface{}) error {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Field", "Value"})
-	table.SetBorder(false)
-	table.SetHeaderLine(false)
-	table.SetRowSeparator("")
-	table.SetCenterSeparator("")
-	table.SetColumnSeparator("  ")
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
+// tableColumnOverhead is the padding and separator space newTableWriter adds
+// around each column.
+const tableColumnOverhead = 4
 
-	// Sort fields for consistent output
-	priorityFields := []string{"id", "name", "title", "email", "description", "type", "created", "updated"}
-	var orderedKeys []string
+// minTableColumnWidth keeps shrunk columns wide enough to stay recognizable.
+// Columns are also never narrower than their header.
+const minTableColumnWidth = 8
 
-	// Add priority fields first
-	for _, field := range priorityFields {
-		if _, exists := data[field]; exists {
-			orderedKeys = append(orderedKeys, field)
+// terminalWidth returns the width of the terminal w writes to, or 0 when w is not
+// a terminal or its size can't be determined.
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		return 0
+	}
+	return width
+}
+
+// fitColumnWidths returns per-column width limits whose sum fits within available.
+// Narrow columns keep their full width; the widest are capped to a common limit,
+// but never below their floor (e.g. the header width), so the table may still
+// overflow when the floors alone don't fit.
+func fitColumnWidths(widths, floors []int, available int) []int {
+	limitFor := func(i, limit int) int {
+		return max(min(widths[i], limit), min(widths[i], floors[i]))
+	}
+	sum := func(limit int) int {
+		total := 0
+		for i := range widths {
+			total += limitFor(i, limit)
 		}
+		return total
 	}
 
-	// Add remaining fields
-	for key := range data {
-		found := false
-		for _, existing := range orderedKeys {
-			if existing == key {
-				found = true
-				break
+	limit := 0
+	for _, w := range widths {
+		limit = max(limit, w)
+	}
+	for limit > 0 && sum(limit) > available {
+		limit--
+	}
+
+	limits := make([]int, len(widths))
+	for i := range widths {
+		limits[i] = limitFor(i, limit)
+	}
+	return limits
+}
+
+// truncateRunes shortens s to at most limit runes, marking the cut with "...".
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	if limit <= 3 {
+		return string(runes[:limit])
+	}
+	return string(runes[:limit-3]) + "..."
+}
+
+// wrapRunes breaks s into lines of at most limit runes, preferring to break at
+// spaces, and keeps at most maxLines of them. When text is cut off, the last
+// line is marked with "...". Lines are joined with newlines.
+func wrapRunes(s string, limit, maxLines int) string {
+	if limit <= 0 {
+		return s
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		runes := []rune(strings.TrimRight(paragraph, "\r"))
+		if len(runes) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		for len(runes) > limit {
+			cut := limit
+			for i := limit; i > 0; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
 			}
+			lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
 		}
-		if !found {
-			orderedKeys = append(orderedKeys, key)
+		if len(runes) > 0 {
+			lines = append(lines, string(runes))
 		}
 	}
 
-	for _, key := range orderedKeys {
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := lines[maxLines-1]
+		if utf8.RuneCountInString(last)+3 > limit {
+			last = truncateRunes(last+"...", limit)
+		} else {
+			last += "..."
+		}
+		lines[maxLines-1] = last
+	}
+	return strings.Join(lines, "\n")
+}
+
+// outputMapTable outputs a single map as a vertical table
+func outputMapTable(w io.Writer, data map[string]interface{}) error {
+	table := newTableWriter(w)
+	table.SetHeader([]string{"Field", "Value"})
+
+	keys := fieldOrder(data)
+
+	// On a terminal the value column gets whatever width the field names leave.
+	valueLimit := 0
+	if width := terminalWidth(w); width > 0 {
+		keyWidth := len("Field")
+		for _, key := range keys {
+			keyWidth = max(keyWidth, utf8.RuneCountInString(TitleCase(key)))
+		}
+		valueLimit = max(width-keyWidth-2*tableColumnOverhead-1, minTableColumnWidth)
+		table.SetAutoWrapText(false)
+	}
+
+	for _, key := range keys {
 		value := formatTableValue(data[key])
+		if valueLimit > 0 {
+			value = truncateRunes(formatTableValueWidth(data[key], 0), valueLimit)
+		}
 		table.Append([]string{TitleCase(key), value})
 	}
 
@@ -163,8 +566,177 @@ func outputMapTable(data map[string]interface{}) error {
 	return nil
 }
 
+// htmlHead is the document prologue for HTML output, with a small inline stylesheet
+// so the report renders cleanly when opened directly or attached to an email.
+const htmlHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>pb report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; font-size: 14px; }
+th, td { border: 1px solid #ddd; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+tr:nth-child(even) td { background: #fafafa; }
+</style>
+</head>
+<body>
+`
+
+// outputHTML prints data as a standalone HTML document containing a table. Slices of
+// maps use the same column order as table output; single maps are shown vertically.
+func outputHTML(w io.Writer, data interface{}) error {
+	var b strings.Builder
+	b.WriteString(htmlHead)
+
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("<p>No data found.</p>\n")
+			break
+		}
+		headers := tableHeaders(v)
+		b.WriteString("<table>\n<thead><tr>")
+		for _, header := range headers {
+			fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(header))
+		}
+		b.WriteString("</tr></thead>\n<tbody>\n")
+		for _, item := range v {
+			b.WriteString("<tr>")
+			for _, header := range headers {
+				fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(formatHTMLValue(item[header])))
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</tbody>\n</table>\n")
+	case map[string]interface{}:
+		b.WriteString("<table>\n<thead><tr><th>Field</th><th>Value</th></tr></thead>\n<tbody>\n")
+		for _, key := range fieldOrder(v) {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(TitleCase(key)), html.EscapeString(formatHTMLValue(v[key])))
+		}
+		b.WriteString("</tbody>\n</table>\n")
+	default:
+		// Fallback to preformatted JSON for complex types
+		output, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Fprintf(&b, "<pre>%s</pre>\n", html.EscapeString(string(output)))
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatHTMLValue formats a value for an HTML cell. Unlike table output, values are
+// not truncated; nested arrays and objects are rendered as compact JSON.
+func formatHTMLValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		// Avoid exponent notation (1.25e+06) for large JSON numbers.
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}, map[string]interface{}:
+		output, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(output)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// CSVOptions controls CSV rendering. The zero value writes comma-separated output
+// with a header row.
+type CSVOptions struct {
+	Delimiter rune // field separator; 0 means ','
+	NoHeader  bool // omit the header row, e.g. when appending to an existing file
+}
+
+// OutputCSV writes a slice of maps as CSV, or a single map as key,value rows.
+// Columns follow the table output order; values are formatted as in HTML output,
+// untruncated with nested arrays and objects as compact JSON.
+func OutputCSV(w io.Writer, data interface{}, opts CSVOptions) error {
+	var rows []map[string]interface{}
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		rows = v
+	case map[string]interface{}:
+		return outputMapCSV(w, v, opts)
+	default:
+		return fmt.Errorf("csv output requires a list of records")
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+
+	headers := tableHeaders(rows)
+	if !opts.NoHeader {
+		if err := writer.Write(headers); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	record := make([]string, len(headers))
+	for _, row := range rows {
+		for i, header := range headers {
+			record[i] = formatHTMLValue(row[header])
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// outputMapCSV writes a single map as two-column key,value CSV, one field per row.
+func outputMapCSV(w io.Writer, data map[string]interface{}, opts CSVOptions) error {
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+
+	if !opts.NoHeader {
+		if err := writer.Write([]string{"key", "value"}); err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	for _, key := range fieldOrder(data) {
+		if err := writer.Write([]string{key, formatHTMLValue(data[key])}); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 // formatTableValue formats a value for table display
 func formatTableValue(value interface{}) string {
+	return formatTableValueWidth(value, defaultTableValueWidth)
+}
+
+// defaultTableValueWidth is the truncation length for table values when the
+// terminal width is unknown.
+const defaultTableValueWidth = 50
+
+// formatTableValueWidth formats a value for table display, truncating long text to
+// maxLen characters. A maxLen of 0 disables truncation.
+func formatTableValueWidth(value interface{}, maxLen int) string {
 	if value == nil {
 		return ""
 	}
@@ -172,8 +744,8 @@ func formatTableValue(value interface{}) string {
 	switch v := value.(type) {
 	case string:
 		// Truncate very long strings for table display
-		if len(v) > 50 {
-			return v[:47] + "..."
+		if maxLen > 0 && len(v) > maxLen {
+			return v[:maxLen-3] + "..."
 		}
 		return v
 	case bool:
@@ -186,9 +758,9 @@ func formatTableValue(value interface{}) string {
 			return "[]"
 		}
 		if len(v) == 1 {
-			return fmt.Sprintf("[%s]", formatTableValue(v[0]))
+			return fmt.Sprintf("[%s]", formatTableValueWidth(v[0], maxLen))
 		}
-		return fmt.Sprintf("[%s, ... (%d items)]", formatTableValue(v[0]), len(v))
+		return fmt.Sprintf("[%s, ... (%d items)]", formatTableValueWidth(v[0], maxLen), len(v))
 	case map[string]interface{}:
 		if len(v) == 0 {
 			return "{}"
@@ -196,69 +768,109 @@ func formatTableValue(value interface{}) string {
 		return fmt.Sprintf("{...} (%d fields)", len(v))
 	default:
 		str := fmt.Sprintf("%v", value)
-		if len(str) > 50 {
-			return str[:47] + "..."
+		if maxLen > 0 && len(str) > maxLen {
+			return str[:maxLen-3] + "..."
 		}
 		return str
 	}
 }
 
+// UseColor reports whether output written to f should be colored: colors must be
+// enabled, and f must be a terminal unless --color=always is set. In auto mode a
+// set NO_COLOR environment variable also turns colors off.
+func UseColor(f *os.File) bool {
+	if !config.Global.ColorsEnabled {
+		return false
+	}
+	switch config.Global.ColorMode {
+	case config.ColorModeAlways:
+		return true
+	case config.ColorModeNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// colored returns a sprint function for attribute that colors regardless of
+// fatih/color's own stdout check; callers decide with UseColor first.
+func colored(attribute color.Attribute) func(a ...interface{}) string {
+	c := color.New(attribute)
+	c.EnableColor()
+	return c.SprintFunc()
+}
+
 // PrintError prints an error message with consistent formatting
 func PrintError(err error) {
-	if !config.Global.ColorsEnabled {
+	if !UseColor(os.Stderr) {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		return
 	}
 
-	red := color.New(color.FgRed).SprintFunc()
+	red := colored(color.FgRed)
 	fmt.Fprintf(os.Stderr, "%s %v\n", red("Error:"), err)
 }
 
 // PrintWarning prints a warning message with consistent formatting
 func PrintWarning(message string) {
-	if !config.Global.ColorsEnabled {
+	if !UseColor(os.Stderr) {
 		fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
 		return
 	}
 
-	yellow := color.New(color.FgYellow).SprintFunc()
+	yellow := colored(color.FgYellow)
 	fmt.Fprintf(os.Stderr, "%s %s\n", yellow("Warning:"), message)
 }
 
 // PrintSuccess prints a success message with consistent formatting
 func PrintSuccess(message string) {
-	if !config.Global.ColorsEnabled {
+	if !UseColor(os.Stdout) {
 		fmt.Printf("Success: %s\n", message)
 		return
 	}
 
-	green := color.New(color.FgGreen).SprintFunc()
+	green := colored(color.FgGreen)
 	fmt.Printf("%s %s\n", green("✓"), message)
 }
 
 // PrintInfo prints an info message with consistent formatting
 func PrintInfo(message string) {
-	if !config.Global.ColorsEnabled {
+	if !UseColor(os.Stdout) {
 		fmt.Printf("Info: %s\n", message)
 		return
 	}
 
-	cyan := color.New(color.FgCyan).SprintFunc()
+	cyan := colored(color.FgCyan)
 	fmt.Printf("%s %s\n", cyan("ℹ"), message)
 }
 
+// TruncateForLog returns at most the first n runes of s followed by "..." when
+// anything was cut, for previewing secrets or long values in debug output. Short
+// and empty strings are returned as is.
+func TruncateForLog(s string, n int) string {
+	if n < 0 {
+		n = 0
+	}
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "..."
+}
+
 // PrintDebug prints a debug message if debug mode is enabled
 func PrintDebug(message string) {
 	if !config.Global.Debug {
 		return
 	}
 
-	if !config.Global.ColorsEnabled {
+	if !UseColor(os.Stderr) {
 		fmt.Fprintf(os.Stderr, "Debug: %s\n", message)
 		return
 	}
 
-	gray := color.New(color.FgHiBlack).SprintFunc()
+	gray := colored(color.FgHiBlack)
 	fmt.Fprintf(os.Stderr, "%s %s\n", gray("Debug:"), message)
 }
 
@@ -310,6 +922,45 @@ func FormatTimeAgo(t time.Time) string {
 	}
 }
 
+// FormatDuration formats a duration compactly with its two largest units, e.g.
+// "3d 4h", "2h 15m", "5m 30s", "45s". Negative durations are treated as zero.
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	total := int(d / time.Second)
+	days, hours, minutes, seconds := total/86400, total%86400/3600, total%3600/60, total%60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	default:
+		return fmt.Sprintf("%ds", seconds)
+	}
+}
+
+// FormatNumber formats an integer with comma thousands separators (e.g. 1,250,000)
+func FormatNumber(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
+
 // FormatBytes formats bytes into human readable format
 func FormatBytes(bytes int64) string {
 	const unit = 1024
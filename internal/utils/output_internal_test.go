@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFitColumnWidths(t *testing.T) {
+	floors := []int{8, 8, 8, 8}
+
+	// Fits already: unchanged.
+	assert.Equal(t, []int{4, 20, 10}, fitColumnWidths([]int{4, 20, 10}, floors, 40))
+
+	// Only the widest columns shrink, to a common limit.
+	assert.Equal(t, []int{4, 13, 10, 13}, fitColumnWidths([]int{4, 40, 10, 30}, floors, 40))
+
+	// Never below the floor, even if the table then overflows.
+	assert.Equal(t, []int{4, 8, 12}, fitColumnWidths([]int{4, 40, 30}, []int{8, 8, 12}, 10))
+}
+
+func TestTruncateRunes(t *testing.T) {
+	assert.Equal(t, "short", truncateRunes("short", 10))
+	assert.Equal(t, "héllo w...", truncateRunes("héllo wörld!", 10))
+	assert.Equal(t, "ab", truncateRunes("abcdef", 2))
+}
+
+func TestFormatTableValueWidth(t *testing.T) {
+	long := "abcdefghijklmnopqrstuvwxyz"
+	assert.Equal(t, long, formatTableValueWidth(long, 0))
+	assert.Equal(t, "abcdefg...", formatTableValueWidth(long, 10))
+}
+
+// ansiPattern matches the SGR escape codes colors add.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColorizeJSON(t *testing.T) {
+	src := []byte("{\n  \"name\": \"a \\\"b\\\": c\",\n  \"count\": -1.5e3,\n  \"ok\": true,\n  \"tags\": [\n    null\n  ]\n}")
+	colorized := colorizeJSON(src)
+
+	assert.Equal(t, string(src), ansiPattern.ReplaceAllString(colorized, ""), "only escape codes are added")
+	assert.Contains(t, colorized, "\x1b[34m\"name\"\x1b[0m:")
+	assert.Contains(t, colorized, "\x1b[32m\"a \\\"b\\\": c\"\x1b[0m")
+	assert.Contains(t, colorized, "\x1b[33m-1.5e3\x1b[0m")
+	assert.Contains(t, colorized, "\x1b[35mtrue\x1b[0m")
+	assert.Contains(t, colorized, "\x1b[35mnull\x1b[0m")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
 	"pb-cli/internal/config"
 	"pb-cli/internal/utils"
 	"strings"
@@ -69,6 +70,23 @@ func TestOutputData(t *testing.T) {
 		assert.Contains(t, output, "✗") // false
 	})
 
+	t.Run("Table Output Right-Aligns Numbers", func(t *testing.T) {
+		data := []map[string]interface{}{
+			{"id": "a", "size": float64(5)},
+			{"id": "b", "size": float64(12345)},
+			{"id": "c", "size": nil},
+		}
+		output := captureOutput(func() {
+			err := utils.OutputData(data, "table")
+			require.NoError(t, err)
+		})
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		require.Len(t, lines, 4)
+		// Right alignment means both numbers end in the same column.
+		assert.Equal(t, len(strings.TrimRight(lines[1], " ")), len(strings.TrimRight(lines[2], " ")))
+		assert.True(t, strings.HasSuffix(strings.TrimRight(lines[1], " "), " 5"))
+	})
+
 	t.Run("Table Output for Single Map", func(t *testing.T) {
 		singleMap := map[string]interface{}{"id": "1", "name": "First Post", "published": true}
 		output := captureOutput(func() {
@@ -80,6 +98,65 @@ func TestOutputData(t *testing.T) {
 		assert.Contains(t, output, "First Post")
 	})
 
+	t.Run("HTML Output", func(t *testing.T) {
+		data := []map[string]interface{}{
+			{"id": "1", "name": "<b>Tom & Jerry</b>"},
+		}
+		output := captureOutput(func() {
+			err := utils.OutputData(data, "html")
+			require.NoError(t, err)
+		})
+		assert.True(t, strings.HasPrefix(output, "<!DOCTYPE html>"))
+		assert.Contains(t, output, "<th>id</th><th>name</th>")
+		assert.Contains(t, output, "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;")
+		assert.NotContains(t, output, "<b>Tom")
+	})
+
+	t.Run("CSV Output", func(t *testing.T) {
+		data := []map[string]interface{}{
+			{"id": "1", "title": "Hello, world", "views": float64(1250000), "tags": []interface{}{"a", "b"}},
+		}
+		var buf bytes.Buffer
+		require.NoError(t, utils.OutputCSV(&buf, data, utils.CSVOptions{}))
+		assert.Equal(t, "id,title,tags,views\n1,\"Hello, world\",\"[\"\"a\"\",\"\"b\"\"]\",1250000\n", buf.String())
+	})
+
+	t.Run("CSV Output Without Header and With Delimiter", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, utils.OutputCSV(&buf, sampleData, utils.CSVOptions{Delimiter: '\t', NoHeader: true}))
+		assert.Equal(t, "1\tFirst Post\ttrue\n2\tSecond Post\tfalse\n", buf.String())
+	})
+
+	t.Run("CSV Output With Differing Fields", func(t *testing.T) {
+		data := []map[string]interface{}{
+			{"id": "1", "title": "Post"},
+			{"id": "2", "body": "Comment"},
+		}
+		var buf bytes.Buffer
+		require.NoError(t, utils.OutputCSV(&buf, data, utils.CSVOptions{}))
+		assert.Equal(t, "id,title,body\n1,Post,\n2,,Comment\n", buf.String())
+	})
+
+	t.Run("NDJSON Output", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, utils.OutputDataTo(&buf, sampleData, "ndjson"))
+		assert.Equal(t, "{\"id\":\"1\",\"name\":\"First Post\",\"published\":true}\n{\"id\":\"2\",\"name\":\"Second Post\",\"published\":false}\n", buf.String())
+
+		buf.Reset()
+		require.NoError(t, utils.OutputDataTo(&buf, sampleData[0], "ndjson"))
+		assert.Equal(t, "{\"id\":\"1\",\"name\":\"First Post\",\"published\":true}\n", buf.String())
+	})
+
+	t.Run("ID Output", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, utils.OutputDataTo(&buf, sampleData[0], "id"))
+		assert.Equal(t, "1\n", buf.String())
+
+		err := utils.OutputDataTo(&buf, sampleData, "id")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "single record")
+	})
+
 	t.Run("Unsupported Format", func(t *testing.T) {
 		err := utils.OutputData(sampleData, "xml")
 		require.Error(t, err)
@@ -141,6 +218,70 @@ func TestFormatBytes(t *testing.T) {
 	}
 }
 
+// TestFormatNumber checks thousands separators.
+func TestFormatNumber(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    int
+		expected string
+	}{
+		{"Zero", 0, "0"},
+		{"Hundreds", 999, "999"},
+		{"Thousands", 1000, "1,000"},
+		{"Millions", 1250000, "1,250,000"},
+		{"Negative", -1234567, "-1,234,567"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, utils.FormatNumber(tc.input))
+		})
+	}
+}
+
+// TestTruncateForLog checks that previews never slice past the end of short values.
+func TestTruncateForLog(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		n        int
+		expected string
+	}{
+		{"Empty", "", 10, ""},
+		{"Shorter", "abc", 10, "abc"},
+		{"Exact", "0123456789", 10, "0123456789"},
+		{"Longer", "0123456789abcdef", 10, "0123456789..."},
+		{"Multibyte", "ééééé", 2, "éé..."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, utils.TruncateForLog(tc.input, tc.n))
+		})
+	}
+}
+
+// TestFormatDuration checks the two-unit duration formatting.
+func TestFormatDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    time.Duration
+		expected string
+	}{
+		{"Negative", -time.Minute, "0s"},
+		{"Seconds", 45 * time.Second, "45s"},
+		{"Minutes", 5*time.Minute + 30*time.Second, "5m 30s"},
+		{"Hours", 2*time.Hour + 15*time.Minute + 10*time.Second, "2h 15m"},
+		{"Days", 76 * time.Hour, "3d 4h"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, utils.FormatDuration(tc.input))
+		})
+	}
+}
+
 // TestFormatTimeAgo checks the relative time formatting.
 func TestFormatTimeAgo(t *testing.T) {
 	now := time.Now()
@@ -165,3 +306,77 @@ func TestFormatTimeAgo(t *testing.T) {
 		})
 	}
 }
+
+// TestOutputTableMaxCellLines checks that long values wrap over up to
+// MaxCellLines lines, with the cut-off marked, instead of being truncated.
+func TestOutputTableMaxCellLines(t *testing.T) {
+	description := strings.Repeat("lorem ipsum ", 20)
+	data := []map[string]interface{}{{"id": "1", "description": description}}
+
+	var single bytes.Buffer
+	require.NoError(t, utils.OutputTable(&single, data, utils.TableOptions{}))
+	assert.Contains(t, single.String(), "...")
+
+	var wrapped bytes.Buffer
+	require.NoError(t, utils.OutputTable(&wrapped, data, utils.TableOptions{MaxCellLines: 3}))
+	lines := strings.Split(strings.TrimRight(wrapped.String(), "\n"), "\n")
+	require.Len(t, lines, 4, "header and three wrapped lines")
+	assert.Contains(t, lines[1], "lorem ipsum")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(lines[3]), "..."))
+
+	var short bytes.Buffer
+	require.NoError(t, utils.OutputTable(&short, []map[string]interface{}{{"id": "1", "description": "brief"}}, utils.TableOptions{MaxCellLines: 3}))
+	assert.Equal(t, 2, strings.Count(short.String(), "\n"))
+}
+
+// TestOutputCSVSingleRecord checks that a single map is written as key,value rows.
+func TestOutputCSVSingleRecord(t *testing.T) {
+	record := map[string]interface{}{
+		"id":    "1",
+		"title": "Hello, world",
+		"meta":  map[string]interface{}{"a": float64(1)},
+		"body":  "line one\nline two",
+	}
+	var buf bytes.Buffer
+	require.NoError(t, utils.OutputCSV(&buf, record, utils.CSVOptions{}))
+	assert.Equal(t, "key,value\nid,1\ntitle,\"Hello, world\"\nbody,\"line one\nline two\"\nmeta,\"{\"\"a\"\":1}\"\n", buf.String())
+}
+
+// TestOutputDataToFile checks that OutputData follows SetDataOutput and that
+// CreateOutputFile creates missing parent directories.
+func TestOutputDataToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exports", "2024", "record.json")
+	f, err := utils.CreateOutputFile(path)
+	require.NoError(t, err)
+
+	utils.SetDataOutput(f)
+	defer utils.SetDataOutput(nil)
+
+	require.NoError(t, utils.OutputData(map[string]interface{}{"id": "1"}, "json"))
+	require.NoError(t, f.Close())
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"id\": \"1\"\n}\n", string(written))
+}
+
+// TestUseColor checks that colors are only used on terminals unless forced.
+func TestUseColor(t *testing.T) {
+	originalEnabled, originalMode := config.Global.ColorsEnabled, config.Global.ColorMode
+	defer func() { config.Global.ColorsEnabled, config.Global.ColorMode = originalEnabled, originalMode }()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	config.Global.ColorsEnabled = true
+	config.Global.ColorMode = config.ColorModeAuto
+	assert.False(t, utils.UseColor(w), "a pipe is not a terminal")
+
+	config.Global.ColorMode = config.ColorModeAlways
+	assert.True(t, utils.UseColor(w))
+
+	config.Global.ColorsEnabled = false
+	assert.False(t, utils.UseColor(w), "colors_enabled: false wins")
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// DefaultProgressInterval is the default percentage step between progress updates.
+const DefaultProgressInterval = 10
+
+// ProgressReporter prints transfer progress every interval percent. On a terminal it
+// redraws a single line in place; otherwise it prints one line per step so logs stay
+// readable.
+type ProgressReporter struct {
+	w        io.Writer
+	interval int
+	tty      bool
+	last     int
+	drawn    bool
+}
+
+// NewProgressReporter creates a reporter writing to w. interval is clamped to 1-100.
+func NewProgressReporter(w io.Writer, interval int) *ProgressReporter {
+	if interval < 1 {
+		interval = 1
+	}
+	if interval > 100 {
+		interval = 100
+	}
+
+	tty := false
+	if f, ok := w.(*os.File); ok {
+		tty = term.IsTerminal(int(f.Fd()))
+	}
+
+	return &ProgressReporter{w: w, interval: interval, tty: tty}
+}
+
+// Update reports that done of total bytes have been transferred. It has the
+// signature of the client's transfer progress callbacks.
+func (p *ProgressReporter) Update(done, total int64) {
+	if total <= 0 {
+		return
+	}
+
+	percent := int((done * 100) / total)
+	if percent > 100 {
+		percent = 100
+	}
+	step := percent - percent%p.interval
+	if percent == 100 {
+		step = 100
+	}
+	if step <= p.last {
+		return
+	}
+	p.last = step
+
+	line := fmt.Sprintf("  Progress: %d%% (%s / %s)", step, FormatBytes(done), FormatBytes(total))
+	if p.tty {
+		// Pad to clear any longer previous line.
+		fmt.Fprintf(p.w, "\r%-50s", line)
+		p.drawn = true
+		return
+	}
+	fmt.Fprintln(p.w, line)
+}
+
+// Finish ends an in-place progress line so later output starts on a new line.
+func (p *ProgressReporter) Finish() {
+	if p.tty && p.drawn {
+		fmt.Fprintln(p.w)
+		p.drawn = false
+	}
+}
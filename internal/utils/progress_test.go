@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"bytes"
+	"pb-cli/internal/utils"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProgressReporter checks that a non-terminal reporter prints one line per step
+// and skips updates that don't cross the next step.
+func TestProgressReporter(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := utils.NewProgressReporter(&buf, 25)
+
+	for _, done := range []int64{10, 24, 26, 30, 55, 99, 100, 100} {
+		reporter.Update(done, 100)
+	}
+	reporter.Finish()
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	assert.Equal(t, []string{
+		"  Progress: 25% (26 B / 100 B)",
+		"  Progress: 50% (55 B / 100 B)",
+		"  Progress: 75% (99 B / 100 B)",
+		"  Progress: 100% (100 B / 100 B)",
+	}, lines)
+}
+
+// TestProgressReporterUnknownTotal checks that nothing is printed without a total size.
+func TestProgressReporterUnknownTotal(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := utils.NewProgressReporter(&buf, 10)
+	reporter.Update(50, 0)
+	assert.Empty(t, buf.String())
+}
@@ -5,16 +5,49 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"syscall"
+
+	"golang.org/x/term"
 )
 
+// stdinReader is shared by all prompts so consecutive prompts reading piped input
+// don't lose data buffered by an earlier reader.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// assumeYesEnv names the environment variable that auto-confirms prompts for automation.
+const assumeYesEnv = "PB_ASSUME_YES"
+
+// assumeYes reports whether PB_ASSUME_YES allows a confirmation to be skipped. It is
+// honored only when stdin is not a terminal, so a variable left exported in an
+// interactive shell can never silently approve a destructive action.
+func assumeYes() bool {
+	switch strings.ToLower(os.Getenv(assumeYesEnv)) {
+	case "1", "true", "yes":
+	default:
+		return false
+	}
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		PrintWarning(fmt.Sprintf("%s is ignored in an interactive session", assumeYesEnv))
+		return false
+	}
+
+	PrintDebug(fmt.Sprintf("Confirmation auto-accepted via %s", assumeYesEnv))
+	return true
+}
+
 // Confirm prints prompt to stderr and reads a yes/no answer from stdin.
 // It returns true only when the user answers "y" or "yes" (case-insensitive).
-// Prompts go to stderr so they never contaminate piped stdout data.
+// Prompts go to stderr so they never contaminate piped stdout data. With
+// PB_ASSUME_YES set in a non-interactive session it returns true without prompting.
 func Confirm(prompt string) (bool, error) {
+	if assumeYes() {
+		return true, nil
+	}
+
 	fmt.Fprint(os.Stderr, prompt)
 
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	response, err := stdinReader.ReadString('\n')
 	if err != nil {
 		return false, fmt.Errorf("failed to read confirmation: %w", err)
 	}
@@ -25,15 +58,53 @@ func Confirm(prompt string) (bool, error) {
 
 // ConfirmWord prints prompt to stderr and requires the user to type an exact
 // word (case-sensitive) to confirm a dangerous operation. It returns true only
-// when the typed response matches word exactly.
+// when the typed response matches word exactly. Like Confirm, it honors PB_ASSUME_YES.
 func ConfirmWord(prompt, word string) (bool, error) {
+	if assumeYes() {
+		return true, nil
+	}
+
 	fmt.Fprint(os.Stderr, prompt)
 
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	response, err := stdinReader.ReadString('\n')
 	if err != nil {
 		return false, fmt.Errorf("failed to read confirmation: %w", err)
 	}
 
 	return strings.TrimSpace(response) == word, nil
 }
+
+// Prompt prints label to stderr and reads a line from stdin. When defaultValue is
+// non-empty it is shown in brackets and returned for an empty answer.
+func Prompt(label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", label)
+	}
+
+	response, err := stdinReader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	response = strings.TrimSpace(response)
+	if response == "" {
+		return defaultValue, nil
+	}
+	return response, nil
+}
+
+// PromptPassword prints label to stderr and reads a line from the terminal without
+// echoing it.
+func PromptPassword(label string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+
+	passwordBytes, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(passwordBytes), nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// QueryPath walks a dotted path such as "expand.author.name" or "items.0.id"
+// through data and returns the value it names. Object keys select fields and
+// numeric segments index arrays. data may be any JSON-encodable value; it is
+// decoded into generic maps and slices before walking.
+func QueryPath(data interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("query path cannot be empty")
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data: %w", err)
+	}
+	var current interface{}
+	if err := json.Unmarshal(encoded, &current); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		walked := strings.Join(segments[:i], ".")
+		if walked == "" {
+			walked = "the top level"
+		}
+		if segment == "" {
+			return nil, fmt.Errorf("invalid query path '%s': empty segment", path)
+		}
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			value, exists := v[segment]
+			if !exists {
+				return nil, fmt.Errorf("'%s' not found at %s", segment, walked)
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, fmt.Errorf("%s is an array; '%s' is not an index", walked, segment)
+			}
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("index %d out of range at %s (%d items)", index, walked, len(v))
+			}
+			current = v[index]
+		default:
+			return nil, fmt.Errorf("cannot look up '%s': %s is %s, not an object or array", segment, walked, jsonKind(current))
+		}
+	}
+
+	return current, nil
+}
+
+// WriteQueryValue writes a value found by QueryPath for use in shell scripts:
+// strings bare and unquoted, null as an empty line, and anything else as compact JSON.
+func WriteQueryValue(w io.Writer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		_, err := fmt.Fprintln(w)
+		return err
+	case string:
+		_, err := fmt.Fprintln(w, v)
+		return err
+	default:
+		return WriteJSONLine(w, v)
+	}
+}
+
+// jsonKind names the JSON type of a decoded value for error messages.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,55 @@
+package utils_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"pb-cli/internal/utils"
+)
+
+func TestQueryPath(t *testing.T) {
+	record := map[string]interface{}{
+		"id":    "abc",
+		"views": 1250000,
+		"tags":  []string{"go", "cli"},
+		"expand": map[string]interface{}{
+			"author": map[string]interface{}{"name": "Ada", "bio": nil},
+		},
+	}
+
+	for path, expected := range map[string]interface{}{
+		"id":                 "abc",
+		"views":              float64(1250000),
+		"tags.1":             "cli",
+		"expand.author.name": "Ada",
+		"expand.author.bio":  nil,
+	} {
+		value, err := utils.QueryPath(record, path)
+		require.NoError(t, err, path)
+		assert.Equal(t, expected, value, path)
+	}
+
+	for _, path := range []string{"", "missing", "tags.2", "tags.x", "id.length", "expand..name"} {
+		_, err := utils.QueryPath(record, path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestWriteQueryValue(t *testing.T) {
+	for _, tc := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{"plain text", "plain text\n"},
+		{float64(1250000), "1250000\n"},
+		{true, "true\n"},
+		{nil, "\n"},
+		{[]interface{}{"a", "b"}, "[\"a\",\"b\"]\n"},
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, utils.WriteQueryValue(&buf, tc.value))
+		assert.Equal(t, tc.expected, buf.String())
+	}
+}
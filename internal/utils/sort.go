@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortRecords sorts records in place by the value of field, descending when field
+// has a "-" prefix. Values of different JSON types are grouped (booleans, then
+// numbers, then strings, then arrays/objects) and missing or null values always sort last,
+// so mixed or sparse columns still produce a stable, predictable order.
+func SortRecords(records []map[string]interface{}, field string) error {
+	descending := strings.HasPrefix(field, "-")
+	field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+")
+	if field == "" {
+		return fmt.Errorf("sort field cannot be empty")
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i][field], records[j][field]
+
+		// Missing values go last in either direction.
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+
+		cmp := compareValues(a, b)
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return nil
+}
+
+// compareValues orders two non-nil decoded JSON values, returning -1, 0, or 1.
+func compareValues(a, b interface{}) int {
+	rankA, rankB := valueRank(a), valueRank(b)
+	if rankA != rankB {
+		return compareInts(rankA, rankB)
+	}
+
+	switch va := a.(type) {
+	case bool:
+		vb := b.(bool)
+		if va == vb {
+			return 0
+		}
+		if !va {
+			return -1
+		}
+		return 1
+	case string:
+		return strings.Compare(va, b.(string))
+	}
+
+	if fa, ok := toFloat(a); ok {
+		fb, _ := toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	}
+
+	// Arrays and objects: compare their JSON encodings for a deterministic order.
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return strings.Compare(string(ja), string(jb))
+}
+
+// valueRank groups values by type for mixed-type sorting.
+func valueRank(value interface{}) int {
+	switch value.(type) {
+	case bool:
+		return 0
+	case string:
+		return 2
+	}
+	if isNumber(value) {
+		return 1
+	}
+	return 3
+}
+
+// toFloat converts a numeric value to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package utils_test
+
+import (
+	"pb-cli/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ids(records []map[string]interface{}) []string {
+	var out []string
+	for _, r := range records {
+		out = append(out, r["id"].(string))
+	}
+	return out
+}
+
+func TestSortRecords(t *testing.T) {
+	records := func() []map[string]interface{} {
+		return []map[string]interface{}{
+			{"id": "a", "views": float64(30), "title": "beta"},
+			{"id": "b", "views": float64(5), "title": "alpha"},
+			{"id": "c", "title": "gamma"},
+			{"id": "d", "views": "n/a", "title": "alpha"},
+			{"id": "e", "views": float64(100), "title": "delta"},
+		}
+	}
+
+	t.Run("Ascending numbers", func(t *testing.T) {
+		data := records()
+		require.NoError(t, utils.SortRecords(data, "views"))
+		// Numbers before strings, missing last.
+		assert.Equal(t, []string{"b", "a", "e", "d", "c"}, ids(data))
+	})
+
+	t.Run("Descending keeps missing last", func(t *testing.T) {
+		data := records()
+		require.NoError(t, utils.SortRecords(data, "-views"))
+		assert.Equal(t, []string{"d", "e", "a", "b", "c"}, ids(data))
+	})
+
+	t.Run("Stable for equal values", func(t *testing.T) {
+		data := records()
+		require.NoError(t, utils.SortRecords(data, "title"))
+		assert.Equal(t, []string{"b", "d", "a", "e", "c"}, ids(data))
+	})
+
+	t.Run("Empty field", func(t *testing.T) {
+		assert.Error(t, utils.SortRecords(records(), "-"))
+	})
+}
@@ -0,0 +1,53 @@
+package utils
+
+// ColumnStats summarizes the values of one numeric table column.
+type ColumnStats struct {
+	Field string
+	Count int // rows with a value; nulls are not counted
+	Min   float64
+	Max   float64
+	Sum   float64
+}
+
+// Avg returns the mean of the column's values.
+func (s ColumnStats) Avg() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// NumericColumnStats returns min/max/sum for every column whose values are
+// numbers in all rows, in table column order. Nulls and missing fields are
+// skipped; a single non-numeric value rules the whole column out, as does a
+// column with no values at all.
+func NumericColumnStats(data []map[string]interface{}) []ColumnStats {
+	var stats []ColumnStats
+	for _, header := range tableHeaders(data) {
+		column := ColumnStats{Field: header}
+		numeric := true
+		for _, item := range data {
+			value := item[header]
+			if value == nil {
+				continue
+			}
+			f, ok := toFloat(value)
+			if !ok {
+				numeric = false
+				break
+			}
+			if column.Count == 0 || f < column.Min {
+				column.Min = f
+			}
+			if column.Count == 0 || f > column.Max {
+				column.Max = f
+			}
+			column.Sum += f
+			column.Count++
+		}
+		if numeric && column.Count > 0 {
+			stats = append(stats, column)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,37 @@
+package utils_test
+
+import (
+	"pb-cli/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumericColumnStats(t *testing.T) {
+	data := []map[string]interface{}{
+		{"id": "a", "price": float64(10), "qty": float64(2), "code": float64(7)},
+		{"id": "b", "price": float64(2.5), "qty": nil, "code": "x7"},
+		{"id": "c", "price": float64(30), "note": nil},
+	}
+
+	stats := utils.NumericColumnStats(data)
+	// code is mixed, id is a string, and note has no values, so only
+	// price and qty qualify.
+	require.Len(t, stats, 2)
+
+	price := stats[0]
+	assert.Equal(t, "price", price.Field)
+	assert.Equal(t, 3, price.Count)
+	assert.Equal(t, 2.5, price.Min)
+	assert.Equal(t, float64(30), price.Max)
+	assert.Equal(t, 42.5, price.Sum)
+	assert.InDelta(t, 14.1667, price.Avg(), 0.0001)
+
+	qty := stats[1]
+	assert.Equal(t, "qty", qty.Field)
+	assert.Equal(t, 1, qty.Count)
+	assert.Equal(t, float64(2), qty.Avg())
+
+	assert.Empty(t, utils.NumericColumnStats(nil))
+}
@@ -1,11 +1,20 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/url"
+	"os"
 	"strings"
 )
 
+// Errors returned by ValidateFileExists, for callers that handle the cases apart.
+var (
+	ErrFileNotFound = errors.New("file not found")
+	ErrIsDirectory  = errors.New("is a directory, not a file")
+)
+
 // ValidateURL validates that a string is a valid and useful URL with a scheme and host.
 func ValidateURL(urlStr string) error {
 	if urlStr == "" {
@@ -41,6 +50,65 @@ func ValidatePocketBaseURL(urlStr string) error {
 	return nil
 }
 
+// sensitiveQueryParams are query parameters that carry credentials, e.g. the file
+// token appended to backup download URLs.
+var sensitiveQueryParams = []string{"token"}
+
+// RedactURL returns urlStr with the values of credential-bearing query parameters
+// replaced, so the URL is safe to log or show in an error. Unparseable input is
+// returned unchanged.
+func RedactURL(urlStr string) string {
+	parsedURL, err := url.Parse(urlStr)
+	if err != nil || parsedURL.RawQuery == "" {
+		return urlStr
+	}
+
+	query := parsedURL.Query()
+	redacted := false
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return urlStr
+	}
+
+	parsedURL.RawQuery = query.Encode()
+	return parsedURL.String()
+}
+
+// ValidateFileExists checks that path names a regular file that can be opened for
+// reading. A missing path wraps ErrFileNotFound, a directory ErrIsDirectory, and
+// an unreadable file fs.ErrPermission.
+func ValidateFileExists(path string) error {
+	if strings.TrimSpace(path) == "" {
+		return fmt.Errorf("file path cannot be empty")
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fmt.Errorf("%w: %s", ErrFileNotFound, path)
+	case errors.Is(err, fs.ErrPermission):
+		return fmt.Errorf("cannot access '%s': %w", path, fs.ErrPermission)
+	case err != nil:
+		return fmt.Errorf("cannot access '%s': %w", path, err)
+	case info.IsDir():
+		return fmt.Errorf("'%s' %w", path, ErrIsDirectory)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return fmt.Errorf("cannot read '%s': %w", path, fs.ErrPermission)
+		}
+		return fmt.Errorf("cannot read '%s': %w", path, err)
+	}
+	return file.Close()
+}
+
 // ValidateEmail validates an email address format (minimal - PocketBase handles detailed validation)
 func ValidateEmail(email string) error {
 	if email == "" {
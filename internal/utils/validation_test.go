@@ -1,6 +1,10 @@
 package utils_test
 
 import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"pb-cli/internal/utils"
 	"testing"
 
@@ -61,3 +65,49 @@ func TestValidatePocketBaseURL(t *testing.T) {
 		})
 	}
 }
+
+// TestRedactURL checks that credential query parameters are masked and other URLs pass through.
+func TestRedactURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Token redacted", "https://pb.example.com/api/backups/b.zip?token=abc.def", "https://pb.example.com/api/backups/b.zip?token=REDACTED"},
+		{"Other params kept", "http://localhost:8090/api/x?page=2&token=secret", "http://localhost:8090/api/x?page=2&token=REDACTED"},
+		{"No query", "http://localhost:8090/api/health", "http://localhost:8090/api/health"},
+		{"No token", "http://localhost:8090/api/x?filter=a%3D1", "http://localhost:8090/api/x?filter=a%3D1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, utils.RedactURL(tc.input))
+		})
+	}
+}
+
+func TestValidateFileExists(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, utils.ValidateFileExists(path))
+	assert.Error(t, utils.ValidateFileExists(" "))
+
+	err := utils.ValidateFileExists(filepath.Join(dir, "missing.json"))
+	assert.True(t, errors.Is(err, utils.ErrFileNotFound), "got %v", err)
+
+	err = utils.ValidateFileExists(dir)
+	assert.True(t, errors.Is(err, utils.ErrIsDirectory), "got %v", err)
+
+	if os.Geteuid() != 0 {
+		locked := filepath.Join(dir, "locked.json")
+		if err := os.WriteFile(locked, []byte(`{}`), 0o000); err != nil {
+			t.Fatal(err)
+		}
+		err = utils.ValidateFileExists(locked)
+		assert.True(t, errors.Is(err, fs.ErrPermission), "got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Package version holds the build metadata stamped into release binaries.
+//
+// Release builds set the variables with ldflags, e.g.
+//
+//	-X pb-cli/internal/version.Version=1.2.3 -X pb-cli/internal/version.Commit=abc1234
+//
+// A plain `go build` leaves Version as "dev" and fills Commit and Date from the
+// VCS information Go records in the binary, when available.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, overridden at link time.
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+func init() {
+	if Commit != "" && Date != "" {
+		return
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if Commit == "" {
+				Commit = setting.Value
+			}
+		case "vcs.time":
+			if Date == "" {
+				Date = setting.Value
+			}
+		}
+	}
+}
+
+// UserAgent returns the User-Agent header sent with API requests.
+func UserAgent() string {
+	return "pb-cli/" + Version
+}
+
+// String describes the build on one line, e.g.
+// "1.2.3 (commit abc1234, built 2024-06-01T10:00:00Z, go1.21.0 linux/amd64)".
+func String() string {
+	commit := Commit
+	if commit == "" {
+		commit = "unknown"
+	} else if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	date := Date
+	if date == "" {
+		date = "unknown"
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s/%s)",
+		Version, commit, date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,18 @@
+package version_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"pb-cli/internal/version"
+)
+
+func TestUserAgentFollowsVersion(t *testing.T) {
+	original := version.Version
+	defer func() { version.Version = original }()
+
+	version.Version = "1.2.3"
+	assert.Equal(t, "pb-cli/1.2.3", version.UserAgent())
+	assert.True(t, strings.HasPrefix(version.String(), "1.2.3 (commit "))
+}